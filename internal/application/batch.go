@@ -0,0 +1,178 @@
+package application
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// MaxBatchOperations - максимальное количество операций в пакетном запросе
+const MaxBatchOperations = 500
+
+// errBatchFailed откатывает транзакцию атомарного пакета, в котором есть ошибочные операции
+var errBatchFailed = errors.New("пакет содержит ошибочные операции")
+
+// ApplyBatch выполняет пакет операций создания, изменения и удаления событий пользователя.
+// Операции выполняются по очереди, и каждая видит результаты предыдущих.
+// В обычном режиме каждая операция выполняется в собственной транзакции независимо от остальных.
+// В атомарном режиме весь пакет выполняется в одной транзакции: если хотя бы одна операция
+// завершилась ошибкой, транзакция откатывается и не применяется ни одна операция.
+// После отмены ctx оставшиеся операции не выполняются и получают статус 503.
+func (s *EventService) ApplyBatch(ctx context.Context, userID int, operations []domain.BatchOperation, policy domain.ConflictPolicy, atomic bool) (*domain.BatchResult, error) {
+	ctx, span := tracer.Start(ctx, "EventService.ApplyBatch")
+	defer span.End()
+
+	if err := s.validator.ValidateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.ValidateBatch(operations); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.ValidateConflictPolicy(policy); err != nil {
+		return nil, err
+	}
+
+	var result *domain.BatchResult
+	if atomic {
+		var err error
+		if result, err = s.applyBatchAtomic(ctx, userID, operations, policy); err != nil {
+			return nil, err
+		}
+	} else {
+		result = s.applyBatchEach(ctx, userID, operations, policy)
+	}
+
+	slog.InfoContext(ctx, "пакет выполнен", "operations", len(operations), "atomic", atomic, "applied", result.Applied)
+	return result, nil
+}
+
+// applyBatchEach выполняет каждую операцию пакета в собственной транзакции
+func (s *EventService) applyBatchEach(ctx context.Context, userID int, operations []domain.BatchOperation, policy domain.ConflictPolicy) *domain.BatchResult {
+	result := &domain.BatchResult{Applied: true, Items: make([]*domain.BatchItemResult, len(operations))}
+	for i, op := range operations {
+		item := newBatchItem(i, op)
+		result.Items[i] = item
+
+		err := s.repo.WithTx(ctx, func(tx domain.EventRepository) error {
+			return s.applyOperation(ctx, tx, userID, op, policy, item)
+		})
+		if err != nil {
+			failBatchItem(item, err)
+			result.Applied = false
+		}
+	}
+
+	return result
+}
+
+// applyBatchAtomic выполняет все операции пакета в одной транзакции
+func (s *EventService) applyBatchAtomic(ctx context.Context, userID int, operations []domain.BatchOperation, policy domain.ConflictPolicy) (*domain.BatchResult, error) {
+	result := &domain.BatchResult{Items: make([]*domain.BatchItemResult, len(operations))}
+
+	err := s.repo.WithTx(ctx, func(tx domain.EventRepository) error {
+		failed := false
+		for i, op := range operations {
+			item := newBatchItem(i, op)
+			result.Items[i] = item
+
+			// Ошибочная операция не прерывает пакет, чтобы клиент получил ошибки всех операций
+			if err := s.applyOperation(ctx, tx, userID, op, policy, item); err != nil {
+				failBatchItem(item, err)
+				failed = true
+			}
+		}
+
+		if failed {
+			return errBatchFailed
+		}
+		return nil
+	})
+
+	switch {
+	case err == nil:
+		result.Applied = true
+	case errors.Is(err, errBatchFailed):
+		// Успешные операции откачены вместе с ошибочными
+		for _, item := range result.Items {
+			if item.Error == "" {
+				item.Status = domain.StatusFailedDependency
+				item.Error = "операция не выполнена из-за ошибок в других операциях пакета"
+				item.Code = domain.CodeFailedDependency
+				item.Event, item.Conflicts = nil, nil
+				if item.Op == domain.ChangeCreate {
+					item.ID = 0
+				}
+			}
+		}
+	default:
+		return nil, domain.NewInternalError("ошибка при выполнении пакета", err)
+	}
+
+	return result, nil
+}
+
+// applyOperation выполняет одну операцию пакета в репозитории repo и записывает результат в item
+func (s *EventService) applyOperation(ctx context.Context, repo domain.EventRepository, userID int, op domain.BatchOperation, policy domain.ConflictPolicy, item *domain.BatchItemResult) error {
+	var err error
+	switch op.Type {
+	case domain.ChangeCreate:
+		item.Event, item.Conflicts, err = s.createIn(ctx, repo, userID, op.Input, policy)
+	case domain.ChangeUpdate:
+		item.Event, item.Conflicts, err = s.updateIn(ctx, repo, op.ID, userID, op.Input, policy)
+	case domain.ChangeDelete:
+		err = s.deleteIn(ctx, repo, op.ID, userID)
+	}
+
+	if err == nil && item.Event != nil {
+		item.ID = item.Event.ID
+	}
+	return err
+}
+
+// newBatchItem создает результат операции пакета
+func newBatchItem(index int, op domain.BatchOperation) *domain.BatchItemResult {
+	return &domain.BatchItemResult{
+		Index:  index,
+		Op:     op.Type,
+		ID:     op.ID,
+		Status: domain.StatusOK,
+	}
+}
+
+// failBatchItem записывает в результат операции ошибку и соответствующий ей статус
+func failBatchItem(item *domain.BatchItemResult, err error) {
+	item.Event = nil
+	item.Details = nil
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		item.Status = domain.StatusServiceUnavailable
+		item.Error = "операция не выполнена: запрос отменен или превысил время ожидания"
+		item.Code = domain.CodeRequestCanceled
+		item.Conflicts = nil
+		return
+	}
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) {
+		item.Status = domain.StatusInternalServerError
+		item.Error = "внутренняя ошибка сервера"
+		item.Code = domain.CodeInternal
+		item.Conflicts = nil
+		return
+	}
+
+	// Причина ошибки (appErr.Err) может раскрывать внутреннее устройство, поэтому не выводится
+	item.Status = appErr.GetStatusCode()
+	item.Error = appErr.Message
+	item.Code = appErr.ErrorCode()
+	item.Details = appErr.Fields
+	item.Conflicts = nil
+
+	var conflictErr *domain.ConflictError
+	if errors.As(err, &conflictErr) {
+		item.Conflicts = conflictErr.Conflicts
+	}
+}
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestApplyBatch(t *testing.T) {
+	date := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
+	own := &domain.Event{ID: 1, UserID: 1, Date: date, Text: "Свое событие"}
+	foreign := &domain.Event{ID: 2, UserID: 2, Date: date, Text: "Чужое событие"}
+
+	operations := []domain.BatchOperation{
+		{Type: domain.ChangeCreate, Input: domain.EventInput{Date: date, Text: "Новое событие"}},
+		{Type: domain.ChangeUpdate, ID: 1, Input: domain.EventInput{Date: date, Text: "Измененное событие"}},
+		{Type: domain.ChangeDelete, ID: 2},
+	}
+
+	setup := func() (*MockEventRepository, *EventService) {
+		mockRepo := new(MockEventRepository)
+		mockRepo.On("GetByID", 1).Return(own, nil)
+		mockRepo.On("GetByID", 2).Return(foreign, nil)
+		return mockRepo, NewEventService(mockRepo, new(MockTagRepository))
+	}
+
+	t.Run("Операции выполняются независимо", func(t *testing.T) {
+		mockRepo, service := setup()
+		mockRepo.On("Create", mock.AnythingOfType("*domain.Event")).Return(nil)
+		mockRepo.On("Update", mock.AnythingOfType("*domain.Event")).Return(nil)
+
+		result, err := service.ApplyBatch(context.Background(), 1, operations, domain.ConflictAllow, false)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Applied)
+		assert.Equal(t, domain.StatusOK, result.Items[0].Status)
+		assert.Equal(t, "Новое событие", result.Items[0].Event.Text)
+		assert.Equal(t, domain.StatusOK, result.Items[1].Status)
+		assert.Equal(t, domain.StatusForbidden, result.Items[2].Status)
+		assert.Equal(t, domain.CodeAccessDenied, result.Items[2].Code)
+		assert.NotEmpty(t, result.Items[2].Error)
+		assert.Empty(t, result.Items[0].Code)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Атомарный пакет с ошибкой откатывается", func(t *testing.T) {
+		mockRepo, service := setup()
+		mockRepo.On("Create", mock.AnythingOfType("*domain.Event")).Return(nil)
+		mockRepo.On("Update", mock.AnythingOfType("*domain.Event")).Return(nil)
+
+		result, err := service.ApplyBatch(context.Background(), 1, operations, domain.ConflictAllow, true)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Applied)
+		assert.Equal(t, domain.StatusFailedDependency, result.Items[0].Status)
+		assert.Nil(t, result.Items[0].Event)
+		assert.Zero(t, result.Items[0].ID)
+		assert.Equal(t, domain.StatusFailedDependency, result.Items[1].Status)
+		assert.Equal(t, domain.CodeFailedDependency, result.Items[1].Code)
+		assert.Equal(t, domain.StatusForbidden, result.Items[2].Status)
+	})
+
+	t.Run("Атомарный пакет без ошибок применяется", func(t *testing.T) {
+		mockRepo, service := setup()
+		mockRepo.On("Create", mock.AnythingOfType("*domain.Event")).Return(nil)
+		mockRepo.On("Delete", 1, 1).Return(nil)
+
+		result, err := service.ApplyBatch(context.Background(), 1, []domain.BatchOperation{
+			operations[0],
+			{Type: domain.ChangeDelete, ID: 1},
+		}, domain.ConflictAllow, true)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Applied)
+		assert.Equal(t, domain.StatusOK, result.Items[0].Status)
+		assert.Equal(t, domain.StatusOK, result.Items[1].Status)
+		mockRepo.AssertCalled(t, "Create", mock.AnythingOfType("*domain.Event"))
+		mockRepo.AssertCalled(t, "Delete", 1, 1)
+	})
+
+	t.Run("Неизвестная операция", func(t *testing.T) {
+		_, service := setup()
+
+		_, err := service.ApplyBatch(context.Background(), 1, []domain.BatchOperation{{Type: "move"}}, domain.ConflictAllow, false)
+
+		assert.Error(t, err)
+		assert.Equal(t, domain.StatusBadRequest, err.(*domain.AppError).GetStatusCode())
+	})
+}
@@ -2,84 +2,290 @@ package application
 
 import (
 	"calendar/internal/domain"
+	"context"
+	"errors"
+	"log/slog"
 	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// MaxEventsRangeSpan - максимальная длина произвольного периода выборки событий
+	MaxEventsRangeSpan = 366 * 24 * time.Hour
+	// MaxAgendaLimit - максимальное количество событий в повестке
+	MaxAgendaLimit = 100
+	// MaxSearchLimit - максимальное количество результатов поиска
+	MaxSearchLimit = 100
+	// MaxSearchQueryLength - максимальная длина поискового запроса в символах
+	MaxSearchQueryLength = 200
+	// MaxPageLimit - максимальный размер страницы событий
+	MaxPageLimit = 500
+	// MaxEventTitleLength - максимальная длина заголовка события в символах
+	MaxEventTitleLength = 200
+	// MaxEventDescriptionLength - максимальная длина описания события в символах
+	MaxEventDescriptionLength = 10000
+	// MaxEventLocationLength - максимальная длина места проведения в символах
+	MaxEventLocationLength = 500
+	// MaxEventURLLength - максимальная длина ссылки на конференцию
+	MaxEventURLLength = 2048
+	// MaxEventProperties - максимальное количество пользовательских свойств события
+	MaxEventProperties = 20
+	// MaxPropertyKeyLength - максимальная длина ключа пользовательского свойства
+	MaxPropertyKeyLength = 64
+	// MaxPropertyValueLength - максимальная длина значения пользовательского свойства в символах
+	MaxPropertyValueLength = 1000
+	// agendaHorizon - на сколько лет вперед ищутся события повестки
+	agendaHorizon = 100
 )
 
+// tracer - трассировщик сервисного слоя; без настроенного провайдера спаны не записываются
+var tracer = otel.Tracer("calendar/internal/application")
+
 // EventService реализует бизнес-логику для работы с событиями
 type EventService struct {
 	repo      domain.EventRepository
+	tagRepo   domain.TagRepository
 	validator *ServiceValidator
+	// now возвращает текущее время; подменяется в тестах
+	now func() time.Time
 }
 
 // NewEventService создает новый экземпляр сервиса событий
-func NewEventService(repo domain.EventRepository) *EventService {
+func NewEventService(repo domain.EventRepository, tagRepo domain.TagRepository) *EventService {
 	return &EventService{
 		repo:      repo,
+		tagRepo:   tagRepo,
 		validator: NewServiceValidator(),
+		now:       time.Now,
+	}
+}
+
+// CreateEvent создает новое событие, проверяя пересечения согласно политике
+func (s *EventService) CreateEvent(ctx context.Context, userID int, input domain.EventInput, policy domain.ConflictPolicy) (*domain.Event, []*domain.Event, error) {
+	ctx, span := tracer.Start(ctx, "EventService.CreateEvent")
+	defer span.End()
+
+	var event *domain.Event
+	var conflicts []*domain.Event
+
+	// Проверка пересечений и сохранение выполняются в одной транзакции
+	err := s.repo.WithTx(ctx, func(tx domain.EventRepository) error {
+		var err error
+		event, conflicts, err = s.createIn(ctx, tx, userID, input, policy)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
 	}
+
+	slog.InfoContext(ctx, "событие создано", "event_id", event.ID, "conflicts", len(conflicts))
+	return event, conflicts, nil
 }
 
-// CreateEvent создает новое событие
-func (s *EventService) CreateEvent(userID int, date time.Time, text string) (*domain.Event, error) {
+// withRepo возвращает копию сервиса, работающую с репозиторием repo, например с транзакцией
+func (s *EventService) withRepo(repo domain.EventRepository) *EventService {
+	scoped := *s
+	scoped.repo = repo
+	return &scoped
+}
+
+// createIn создает событие в репозитории repo, например в транзакции
+func (s *EventService) createIn(ctx context.Context, repo domain.EventRepository, userID int, input domain.EventInput, policy domain.ConflictPolicy) (*domain.Event, []*domain.Event, error) {
+	s = s.withRepo(repo)
+
 	// Валидация входных данных
-	if err := s.validator.ValidateEventData(userID, text); err != nil {
-		return nil, err
+	if err := s.validator.ValidateEventData(userID, input.Text); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.validator.ValidateEventPeriod(input.Date, input.EndDate); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.validator.ValidateEventDetails(input); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.validator.ValidateConflictPolicy(policy); err != nil {
+		return nil, nil, err
+	}
+
+	tags, err := resolveTags(s.tagRepo, userID, input.Tags)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Создаем событие
 	event := &domain.Event{
-		UserID:    userID,
-		Date:      date,
-		Text:      text,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		UserID:      userID,
+		Date:        input.Date,
+		EndDate:     input.EndDate,
+		Text:        input.Text,
+		Title:       input.Title,
+		Description: input.Description,
+		Location:    input.Location,
+		URL:         input.URL,
+		Properties:  input.Properties,
+		Tags:        tags,
+		CreatedAt:   s.now(),
+		UpdatedAt:   s.now(),
+	}
+
+	// Проверяем пересечения
+	conflicts, err := s.checkConflicts(ctx, event, policy)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Сохраняем в репозитории
-	if err := s.repo.Create(event); err != nil {
-		return nil, domain.NewInternalError("ошибка при создании события", err)
+	if err := s.repo.Create(ctx, event); err != nil {
+		return nil, nil, domain.NewInternalError("ошибка при создании события", err)
+	}
+
+	return event, conflicts, nil
+}
+
+// UpdateEvent обновляет существующее событие, проверяя пересечения согласно политике
+func (s *EventService) UpdateEvent(ctx context.Context, id int, userID int, input domain.EventInput, policy domain.ConflictPolicy) (*domain.Event, []*domain.Event, error) {
+	ctx, span := tracer.Start(ctx, "EventService.UpdateEvent")
+	defer span.End()
+
+	var updated *domain.Event
+	var conflicts []*domain.Event
+
+	// Проверка владельца, пересечений и запись выполняются в одной транзакции,
+	// чтобы событие не изменилось и не было удалено между проверкой и записью
+	err := s.repo.WithTx(ctx, func(tx domain.EventRepository) error {
+		var err error
+		updated, conflicts, err = s.updateIn(ctx, tx, id, userID, input, policy)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return event, nil
+	slog.InfoContext(ctx, "событие обновлено", "event_id", id, "conflicts", len(conflicts))
+	return updated, conflicts, nil
 }
 
-// UpdateEvent обновляет существующее событие
-func (s *EventService) UpdateEvent(id int, userID int, date time.Time, text string) (*domain.Event, error) {
+// updateIn обновляет событие в репозитории repo, например в транзакции
+func (s *EventService) updateIn(ctx context.Context, repo domain.EventRepository, id int, userID int, input domain.EventInput, policy domain.ConflictPolicy) (*domain.Event, []*domain.Event, error) {
+	s = s.withRepo(repo)
+
 	// Валидация входных данных
-	if err := s.validator.ValidateEventData(userID, text); err != nil {
-		return nil, err
+	if err := s.validator.ValidateEventData(userID, input.Text); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.validator.ValidateEventPeriod(input.Date, input.EndDate); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.validator.ValidateEventDetails(input); err != nil {
+		return nil, nil, err
 	}
 
 	if err := s.validator.ValidateEventID(id); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	if err := s.validator.ValidateConflictPolicy(policy); err != nil {
+		return nil, nil, err
 	}
 
 	// Получаем существующее событие
-	event, err := s.repo.GetByID(id)
+	event, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, domain.NewNotFoundError("событие не найдено")
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		return nil, nil, domain.NewNotFoundError("событие не найдено").WithCode(domain.CodeEventNotFound)
 	}
 
 	// Проверяем права доступа
 	if event.UserID != userID {
-		return nil, domain.NewAccessDeniedError("нет прав для изменения этого события")
+		return nil, nil, domain.NewAccessDeniedError("нет прав для изменения этого события")
+	}
+
+	tags, err := resolveTags(s.tagRepo, userID, input.Tags)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Обновляем поля
-	event.Date = date
-	event.Text = text
-	event.UpdatedAt = time.Now()
+	// Проверяем пересечения для новой версии события, не трогая сохраненную
+	updated := *event
+	updated.Date = input.Date
+	updated.EndDate = input.EndDate
+	updated.Text = input.Text
+	updated.Title = input.Title
+	updated.Description = input.Description
+	updated.Location = input.Location
+	updated.URL = input.URL
+	updated.Properties = input.Properties
+	updated.Tags = tags
+	updated.UpdatedAt = s.now()
+
+	conflicts, err := s.checkConflicts(ctx, &updated, policy)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Сохраняем изменения
-	if err := s.repo.Update(event); err != nil {
-		return nil, domain.NewInternalError("ошибка при обновлении события", err)
+	if err := s.repo.Update(ctx, &updated); err != nil {
+		return nil, nil, domain.NewInternalError("ошибка при обновлении события", err)
+	}
+
+	return &updated, conflicts, nil
+}
+
+// checkConflicts ищет события пользователя, пересекающиеся с event, и применяет политику
+func (s *EventService) checkConflicts(ctx context.Context, event *domain.Event, policy domain.ConflictPolicy) ([]*domain.Event, error) {
+	if policy == domain.ConflictAllow {
+		return nil, nil
+	}
+
+	candidates, err := s.repo.GetOverlapping(ctx, event.UserID, event.Date, event.End())
+	if err != nil {
+		return nil, domain.NewInternalError("ошибка при проверке пересечений", err)
+	}
+
+	var conflicts []*domain.Event
+	for _, candidate := range candidates {
+		// При изменении событие не пересекается само с собой
+		if candidate.ID != event.ID {
+			conflicts = append(conflicts, candidate)
+		}
 	}
 
-	return event, nil
+	if len(conflicts) > 0 && policy == domain.ConflictReject {
+		return nil, domain.NewConflictError("событие пересекается с существующими событиями", conflicts)
+	}
+
+	return conflicts, nil
 }
 
 // DeleteEvent удаляет событие
-func (s *EventService) DeleteEvent(id int, userID int) error {
+func (s *EventService) DeleteEvent(ctx context.Context, id int, userID int) error {
+	ctx, span := tracer.Start(ctx, "EventService.DeleteEvent")
+	defer span.End()
+
+	// Проверка владельца и удаление выполняются в одной транзакции
+	err := s.repo.WithTx(ctx, func(tx domain.EventRepository) error {
+		return s.deleteIn(ctx, tx, id, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "событие удалено", "event_id", id)
+	return nil
+}
+
+// deleteIn удаляет событие в репозитории repo, например в транзакции
+func (s *EventService) deleteIn(ctx context.Context, repo domain.EventRepository, id int, userID int) error {
+	s = s.withRepo(repo)
+
 	// Валидация входных данных
 	if err := s.validator.ValidateEventID(id); err != nil {
 		return err
@@ -90,9 +296,12 @@ func (s *EventService) DeleteEvent(id int, userID int) error {
 	}
 
 	// Получаем существующее событие
-	event, err := s.repo.GetByID(id)
+	event, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return domain.NewNotFoundError("событие не найдено")
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return domain.NewNotFoundError("событие не найдено").WithCode(domain.CodeEventNotFound)
 	}
 
 	// Проверяем права доступа
@@ -101,48 +310,172 @@ func (s *EventService) DeleteEvent(id int, userID int) error {
 	}
 
 	// Удаляем событие
-	if err := s.repo.Delete(id, userID); err != nil {
+	if err := s.repo.Delete(ctx, id, userID); err != nil {
 		return domain.NewInternalError("ошибка при удалении события", err)
 	}
 
 	return nil
 }
 
-// getEventsByUserID общий метод для получения событий пользователя
-func (s *EventService) getEventsByUserID(userID int, getter func(int, time.Time) ([]*domain.Event, error), date time.Time) ([]*domain.Event, error) {
+// listEvents общий метод для постраничного получения событий пользователя в диапазоне [startDate, endDate]
+func (s *EventService) listEvents(ctx context.Context, userID int, startDate, endDate time.Time, page domain.PageRequest) (*domain.EventPage, error) {
 	if err := s.validator.ValidateUserID(userID); err != nil {
 		return nil, err
 	}
 
-	events, err := getter(userID, date)
+	if err := s.validator.ValidatePageRequest(page); err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.ListByUserAndDateRange(ctx, userID, startDate, endDate, page)
 	if err != nil {
+		var appErr *domain.AppError
+		if errors.As(err, &appErr) && appErr.GetStatusCode() == domain.StatusBadRequest {
+			// Некорректный курсор - ошибка клиента
+			return nil, err
+		}
 		return nil, domain.NewInternalError("ошибка при получении событий", err)
 	}
 
-	return events, nil
+	return result, nil
 }
 
 // GetEventsForDay возвращает события на конкретный день
-func (s *EventService) GetEventsForDay(userID int, date time.Time) ([]*domain.Event, error) {
-	return s.getEventsByUserID(userID, s.repo.GetByUserAndDate, date)
+func (s *EventService) GetEventsForDay(ctx context.Context, userID int, date time.Time, page domain.PageRequest) (*domain.EventPage, error) {
+	ctx, span := tracer.Start(ctx, "EventService.GetEventsForDay")
+	defer span.End()
+
+	startDate := startOfDay(date)
+	endDate := startDate.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	return s.listEvents(ctx, userID, startDate, endDate, page)
+}
+
+// GetEventsForWeek возвращает события на неделю, начиная с указанной даты.
+// Границы считаются по календарю в часовом поясе даты, поэтому корректны и для дней перехода на летнее время.
+func (s *EventService) GetEventsForWeek(ctx context.Context, userID int, startDate time.Time, page domain.PageRequest) (*domain.EventPage, error) {
+	ctx, span := tracer.Start(ctx, "EventService.GetEventsForWeek")
+	defer span.End()
+
+	startDate = startOfDay(startDate)
+	endDate := startDate.AddDate(0, 0, 7).Add(-time.Nanosecond)
+	return s.listEvents(ctx, userID, startDate, endDate, page)
 }
 
-// GetEventsForWeek возвращает события на неделю, начиная с указанной даты
-func (s *EventService) GetEventsForWeek(userID int, startDate time.Time) ([]*domain.Event, error) {
-	endDate := startDate.AddDate(0, 0, 7)
-	return s.getEventsByUserID(userID, func(uid int, date time.Time) ([]*domain.Event, error) {
-		return s.repo.GetByUserAndDateRange(uid, startDate, endDate)
-	}, startDate)
+// GetEventsForCalendarWeek возвращает события календарной недели, содержащей дату,
+// с учетом первого дня недели пользователя
+func (s *EventService) GetEventsForCalendarWeek(ctx context.Context, userID int, date time.Time, weekStart time.Weekday, page domain.PageRequest) (*domain.EventPage, error) {
+	ctx, span := tracer.Start(ctx, "EventService.GetEventsForCalendarWeek")
+	defer span.End()
+
+	return s.GetEventsForWeek(ctx, userID, startOfWeek(date, weekStart), page)
 }
 
 // GetEventsForMonth возвращает события на месяц
-func (s *EventService) GetEventsForMonth(userID int, yearMonth time.Time) ([]*domain.Event, error) {
+func (s *EventService) GetEventsForMonth(ctx context.Context, userID int, yearMonth time.Time, page domain.PageRequest) (*domain.EventPage, error) {
+	ctx, span := tracer.Start(ctx, "EventService.GetEventsForMonth")
+	defer span.End()
+
 	// Начало месяца
 	startDate := time.Date(yearMonth.Year(), yearMonth.Month(), 1, 0, 0, 0, 0, yearMonth.Location())
 	// Конец месяца
 	endDate := startDate.AddDate(0, 1, 0).Add(-time.Nanosecond)
 
-	return s.getEventsByUserID(userID, func(uid int, date time.Time) ([]*domain.Event, error) {
-		return s.repo.GetByUserAndDateRange(uid, startDate, endDate)
-	}, yearMonth)
+	return s.listEvents(ctx, userID, startDate, endDate, page)
+}
+
+// GetEventsForRange возвращает события в произвольном периоде [from, to)
+func (s *EventService) GetEventsForRange(ctx context.Context, userID int, from, to time.Time, page domain.PageRequest) (*domain.EventPage, error) {
+	ctx, span := tracer.Start(ctx, "EventService.GetEventsForRange")
+	defer span.End()
+
+	if err := s.validator.ValidateTimeRange(from, to, MaxEventsRangeSpan); err != nil {
+		return nil, err
+	}
+
+	return s.listEvents(ctx, userID, from, to.Add(-time.Nanosecond), page)
+}
+
+// GetEventsForYear возвращает события года, сгруппированные по месяцам
+func (s *EventService) GetEventsForYear(ctx context.Context, userID int, year time.Time) ([]*domain.MonthSummary, error) {
+	ctx, span := tracer.Start(ctx, "EventService.GetEventsForYear")
+	defer span.End()
+
+	if err := s.validator.ValidateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	startDate := time.Date(year.Year(), time.January, 1, 0, 0, 0, 0, year.Location())
+	endDate := startDate.AddDate(1, 0, 0).Add(-time.Nanosecond)
+
+	events, err := s.repo.GetByUserAndDateRange(ctx, userID, startDate, endDate)
+	if err != nil {
+		return nil, domain.NewInternalError("ошибка при получении событий", err)
+	}
+
+	summary := make([]*domain.MonthSummary, 12)
+	for i := range summary {
+		summary[i] = &domain.MonthSummary{
+			Month:  startDate.AddDate(0, i, 0).Format("2006-01"),
+			Events: []*domain.Event{},
+		}
+	}
+	for _, event := range events {
+		month := summary[event.Date.In(year.Location()).Month()-1]
+		month.Events = append(month.Events, event)
+		month.Count++
+	}
+
+	return summary, nil
+}
+
+// GetAgenda возвращает ближайшие события, начинающиеся не раньше from.
+// Размер страницы обязателен; следующую страницу можно получить по курсору.
+func (s *EventService) GetAgenda(ctx context.Context, userID int, from time.Time, page domain.PageRequest) (*domain.EventPage, error) {
+	ctx, span := tracer.Start(ctx, "EventService.GetAgenda")
+	defer span.End()
+
+	if err := s.validator.ValidateLimit(page.Limit, MaxAgendaLimit); err != nil {
+		return nil, err
+	}
+
+	// Повестка всегда упорядочена по дате начала
+	page.Sort = domain.SortByDate
+	return s.listEvents(ctx, userID, from, from.AddDate(agendaHorizon, 0, 0), page)
+}
+
+// SearchEvents ищет события пользователя по тексту
+func (s *EventService) SearchEvents(ctx context.Context, userID int, query string, limit int) ([]*domain.SearchResult, error) {
+	ctx, span := tracer.Start(ctx, "EventService.SearchEvents")
+	defer span.End()
+
+	if err := s.validator.ValidateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.ValidateSearchQuery(query); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.ValidateLimit(limit, MaxSearchLimit); err != nil {
+		return nil, err
+	}
+
+	results, err := s.repo.Search(ctx, userID, query, limit)
+	if err != nil {
+		return nil, domain.NewInternalError("ошибка при поиске событий", err)
+	}
+
+	return results, nil
+}
+
+// startOfDay возвращает полночь дня t в его часовом поясе
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek возвращает полночь первого дня недели, содержащей t
+func startOfWeek(t time.Time, weekStart time.Weekday) time.Time {
+	day := startOfDay(t)
+	diff := (int(day.Weekday()) - int(weekStart) + 7) % 7
+	return day.AddDate(0, 0, -diff)
 }
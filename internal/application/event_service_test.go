@@ -2,6 +2,10 @@ package application
 
 import (
 	"calendar/internal/domain"
+	"context"
+	"errors"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,27 +13,27 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-// MockEventRepository - мок для EventRepository
+// MockEventRepository - мок для EventRepository; контекст не участвует в ожиданиях
 type MockEventRepository struct {
 	mock.Mock
 }
 
-func (m *MockEventRepository) Create(event *domain.Event) error {
+func (m *MockEventRepository) Create(ctx context.Context, event *domain.Event) error {
 	args := m.Called(event)
 	return args.Error(0)
 }
 
-func (m *MockEventRepository) Update(event *domain.Event) error {
+func (m *MockEventRepository) Update(ctx context.Context, event *domain.Event) error {
 	args := m.Called(event)
 	return args.Error(0)
 }
 
-func (m *MockEventRepository) Delete(id int, userID int) error {
+func (m *MockEventRepository) Delete(ctx context.Context, id int, userID int) error {
 	args := m.Called(id, userID)
 	return args.Error(0)
 }
 
-func (m *MockEventRepository) GetByID(id int) (*domain.Event, error) {
+func (m *MockEventRepository) GetByID(ctx context.Context, id int) (*domain.Event, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -37,7 +41,7 @@ func (m *MockEventRepository) GetByID(id int) (*domain.Event, error) {
 	return args.Get(0).(*domain.Event), args.Error(1)
 }
 
-func (m *MockEventRepository) GetByUserAndDate(userID int, date time.Time) ([]*domain.Event, error) {
+func (m *MockEventRepository) GetByUserAndDate(ctx context.Context, userID int, date time.Time) ([]*domain.Event, error) {
 	args := m.Called(userID, date)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -45,7 +49,7 @@ func (m *MockEventRepository) GetByUserAndDate(userID int, date time.Time) ([]*d
 	return args.Get(0).([]*domain.Event), args.Error(1)
 }
 
-func (m *MockEventRepository) GetByUserAndDateRange(userID int, startDate, endDate time.Time) ([]*domain.Event, error) {
+func (m *MockEventRepository) GetByUserAndDateRange(ctx context.Context, userID int, startDate, endDate time.Time) ([]*domain.Event, error) {
 	args := m.Called(userID, startDate, endDate)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -53,6 +57,40 @@ func (m *MockEventRepository) GetByUserAndDateRange(userID int, startDate, endDa
 	return args.Get(0).([]*domain.Event), args.Error(1)
 }
 
+func (m *MockEventRepository) GetOverlapping(ctx context.Context, userID int, start, end time.Time) ([]*domain.Event, error) {
+	args := m.Called(userID, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Event), args.Error(1)
+}
+
+func (m *MockEventRepository) ListByUserAndDateRange(ctx context.Context, userID int, startDate, endDate time.Time, page domain.PageRequest) (*domain.EventPage, error) {
+	args := m.Called(userID, startDate, endDate, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EventPage), args.Error(1)
+}
+
+func (m *MockEventRepository) Search(ctx context.Context, userID int, query string, limit int) ([]*domain.SearchResult, error) {
+	args := m.Called(userID, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SearchResult), args.Error(1)
+}
+
+func (m *MockEventRepository) ReplaceTag(ctx context.Context, userID int, oldName, newName string) error {
+	args := m.Called(userID, oldName, newName)
+	return args.Error(0)
+}
+
+// WithTx выполняет fn с самим моком в роли транзакции; откат не моделируется
+func (m *MockEventRepository) WithTx(ctx context.Context, fn func(tx domain.EventRepository) error) error {
+	return fn(m)
+}
+
 func TestCreateEvent(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -90,13 +128,13 @@ func TestCreateEvent(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockEventRepository)
-			service := NewEventService(mockRepo)
+			service := NewEventService(mockRepo, new(MockTagRepository))
 
 			if !tt.expectError {
 				mockRepo.On("Create", mock.AnythingOfType("*domain.Event")).Return(nil)
 			}
 
-			event, err := service.CreateEvent(tt.userID, tt.date, tt.text)
+			event, _, err := service.CreateEvent(context.Background(), tt.userID, domain.EventInput{Date: tt.date, Text: tt.text}, domain.ConflictAllow)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -120,6 +158,63 @@ func TestCreateEvent(t *testing.T) {
 	}
 }
 
+func TestCreateEvent_Details(t *testing.T) {
+	date := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		input       domain.EventInput
+		expectError bool
+	}{
+		{
+			name: "Все поля заполнены",
+			input: domain.EventInput{
+				Title:       "Созвон",
+				Description: "Обсуждение релиза",
+				Location:    &domain.Location{Text: "Переговорная", Geo: &domain.GeoPoint{Lat: 55.75, Lon: 37.62}},
+				URL:         "https://meet.example.com/abc",
+				Properties:  map[string]string{"crm.deal_id": "42"},
+			},
+		},
+		{name: "Слишком длинный заголовок", input: domain.EventInput{Title: strings.Repeat("я", MaxEventTitleLength+1)}, expectError: true},
+		{name: "Широта вне диапазона", input: domain.EventInput{Location: &domain.Location{Geo: &domain.GeoPoint{Lat: 91}}}, expectError: true},
+		{name: "Широта NaN", input: domain.EventInput{Location: &domain.Location{Geo: &domain.GeoPoint{Lat: math.NaN()}}}, expectError: true},
+		{name: "Долгота NaN", input: domain.EventInput{Location: &domain.Location{Geo: &domain.GeoPoint{Lon: math.NaN()}}}, expectError: true},
+		{name: "Бесконечная долгота", input: domain.EventInput{Location: &domain.Location{Geo: &domain.GeoPoint{Lon: math.Inf(1)}}}, expectError: true},
+		{name: "Пустое место проведения", input: domain.EventInput{Location: &domain.Location{}}, expectError: true},
+		{name: "Относительная ссылка", input: domain.EventInput{URL: "/meet/abc"}, expectError: true},
+		{name: "Ссылка не http", input: domain.EventInput{URL: "javascript:alert(1)"}, expectError: true},
+		{name: "Некорректный ключ свойства", input: domain.EventInput{Properties: map[string]string{"ключ": "значение"}}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEventRepository)
+			service := NewEventService(mockRepo, new(MockTagRepository))
+			mockRepo.On("Create", mock.AnythingOfType("*domain.Event")).Return(nil)
+
+			input := tt.input
+			input.Date = date
+			input.Text = "Событие"
+
+			event, _, err := service.CreateEvent(context.Background(), 1, input, domain.ConflictAllow)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Equal(t, domain.StatusBadRequest, err.(*domain.AppError).GetStatusCode())
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.input.Title, event.Title)
+			assert.Equal(t, tt.input.Location, event.Location)
+			assert.Equal(t, tt.input.URL, event.URL)
+			assert.Equal(t, tt.input.Properties, event.Properties)
+		})
+	}
+}
+
 func TestUpdateEvent(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -175,7 +270,7 @@ func TestUpdateEvent(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockEventRepository)
-			service := NewEventService(mockRepo)
+			service := NewEventService(mockRepo, new(MockTagRepository))
 
 			if tt.existingEvent != nil {
 				mockRepo.On("GetByID", tt.id).Return(tt.existingEvent, nil)
@@ -187,7 +282,7 @@ func TestUpdateEvent(t *testing.T) {
 				mockRepo.On("GetByID", tt.id).Return(nil, domain.NewNotFoundError("событие не найдено"))
 			}
 
-			event, err := service.UpdateEvent(tt.id, tt.userID, tt.date, tt.text)
+			event, _, err := service.UpdateEvent(context.Background(), tt.id, tt.userID, domain.EventInput{Date: tt.date, Text: tt.text}, domain.ConflictAllow)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -260,7 +355,7 @@ func TestDeleteEvent(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockEventRepository)
-			service := NewEventService(mockRepo)
+			service := NewEventService(mockRepo, new(MockTagRepository))
 
 			if tt.existingEvent != nil {
 				mockRepo.On("GetByID", tt.id).Return(tt.existingEvent, nil)
@@ -272,7 +367,7 @@ func TestDeleteEvent(t *testing.T) {
 				mockRepo.On("GetByID", tt.id).Return(nil, domain.NewNotFoundError("событие не найдено"))
 			}
 
-			err := service.DeleteEvent(tt.id, tt.userID)
+			err := service.DeleteEvent(context.Background(), tt.id, tt.userID)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -281,10 +376,12 @@ func TestDeleteEvent(t *testing.T) {
 					appErr, ok := err.(*domain.AppError)
 					assert.True(t, ok)
 					assert.Equal(t, domain.StatusNotFound, appErr.GetStatusCode())
+					assert.Equal(t, domain.CodeEventNotFound, appErr.ErrorCode())
 				case "access_denied":
 					appErr, ok := err.(*domain.AppError)
 					assert.True(t, ok)
 					assert.Equal(t, domain.StatusForbidden, appErr.GetStatusCode())
+					assert.Equal(t, domain.CodeAccessDenied, appErr.ErrorCode())
 				}
 			} else {
 				assert.NoError(t, err)
@@ -294,3 +391,263 @@ func TestDeleteEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateEvent_Conflicts(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2025, 12, 18, hour, 0, 0, 0, time.UTC)
+	}
+	existing := []*domain.Event{
+		{ID: 1, UserID: 1, Date: at(10), EndDate: at(11), Text: "Встреча"},
+		{ID: 2, UserID: 1, Date: at(12), EndDate: at(13), Text: "Обед"},
+	}
+
+	tests := []struct {
+		name          string
+		policy        domain.ConflictPolicy
+		start, end    time.Time
+		overlapping   []*domain.Event
+		expectError   bool
+		expectCreated bool
+		conflicts     int
+	}{
+		{name: "Без пересечений", policy: domain.ConflictReject, start: at(11), end: at(12), expectCreated: true},
+		{name: "Предупреждение о пересечении", policy: domain.ConflictWarn, start: at(10), end: at(13), overlapping: existing, expectCreated: true, conflicts: 2},
+		{name: "Отказ при пересечении", policy: domain.ConflictReject, start: at(9), end: at(11), overlapping: existing[:1], expectError: true, conflicts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEventRepository)
+			service := NewEventService(mockRepo, new(MockTagRepository))
+
+			mockRepo.On("GetOverlapping", 1, tt.start, tt.end).Return(tt.overlapping, nil)
+			if tt.expectCreated {
+				mockRepo.On("Create", mock.AnythingOfType("*domain.Event")).Return(nil)
+			}
+
+			event, conflicts, err := service.CreateEvent(context.Background(), 1, domain.EventInput{Date: tt.start, EndDate: tt.end, Text: "Новое событие"}, tt.policy)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				var conflictErr *domain.ConflictError
+				assert.True(t, errors.As(err, &conflictErr))
+				assert.Len(t, conflictErr.Conflicts, tt.conflicts)
+				// Ошибка пересечения остается ошибкой приложения
+				var appErr *domain.AppError
+				assert.True(t, errors.As(err, &appErr))
+				assert.Equal(t, domain.StatusConflict, appErr.GetStatusCode())
+				assert.Equal(t, domain.CodeEventConflict, appErr.ErrorCode())
+				assert.Nil(t, event)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, event)
+				assert.Len(t, conflicts, tt.conflicts)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateEvent_IgnoresSelfConflict(t *testing.T) {
+	mockRepo := new(MockEventRepository)
+	service := NewEventService(mockRepo, new(MockTagRepository))
+
+	date := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
+	existing := &domain.Event{ID: 1, UserID: 1, Date: date, EndDate: date.Add(time.Hour), Text: "Встреча"}
+
+	mockRepo.On("GetByID", 1).Return(existing, nil)
+	mockRepo.On("GetOverlapping", 1, date.Add(30*time.Minute), date.Add(90*time.Minute)).Return([]*domain.Event{existing}, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*domain.Event")).Return(nil)
+
+	event, conflicts, err := service.UpdateEvent(context.Background(), 1, 1, domain.EventInput{Date: date.Add(30 * time.Minute), EndDate: date.Add(90 * time.Minute), Text: "Перенесенная встреча"}, domain.ConflictReject)
+	assert.NoError(t, err)
+	assert.Empty(t, conflicts)
+	assert.Equal(t, "Перенесенная встреча", event.Text)
+	// Сохраненное событие не изменяется до вызова Update
+	assert.Equal(t, "Встреча", existing.Text)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateEvent_Canceled(t *testing.T) {
+	mockRepo := new(MockEventRepository)
+	service := NewEventService(mockRepo, new(MockTagRepository))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mockRepo.On("GetByID", 1).Return(nil, context.Canceled)
+
+	// Отмена запроса не выдается за отсутствие события
+	_, _, err := service.UpdateEvent(ctx, 1, 1, domain.EventInput{Date: time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC), Text: "Встреча"}, domain.ConflictAllow)
+	assert.ErrorIs(t, err, context.Canceled)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestGetEventsForWeek_TimeZone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	mockRepo := new(MockEventRepository)
+	service := NewEventService(mockRepo, new(MockTagRepository))
+
+	// Неделя с переходом на летнее время короче на час, границы остаются полуночами
+	start := time.Date(2025, 3, 27, 0, 0, 0, 0, berlin)
+	end := time.Date(2025, 4, 3, 0, 0, 0, 0, berlin).Add(-time.Nanosecond)
+	mockRepo.On("ListByUserAndDateRange", 1, start, end, domain.PageRequest{}).Return(&domain.EventPage{}, nil)
+
+	_, err = service.GetEventsForWeek(context.Background(), 1, time.Date(2025, 3, 27, 15, 0, 0, 0, berlin), domain.PageRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour-time.Hour-time.Nanosecond, end.Sub(start))
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetEventsForCalendarWeek(t *testing.T) {
+	// 18 декабря 2025 года - четверг
+	date := time.Date(2025, 12, 18, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		weekStart time.Weekday
+		start     time.Time
+	}{
+		{name: "Неделя с понедельника", weekStart: time.Monday, start: time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "Неделя с воскресенья", weekStart: time.Sunday, start: time.Date(2025, 12, 14, 0, 0, 0, 0, time.UTC)},
+		{name: "Неделя с субботы", weekStart: time.Saturday, start: time.Date(2025, 12, 13, 0, 0, 0, 0, time.UTC)},
+		{name: "Дата совпадает с началом недели", weekStart: time.Thursday, start: time.Date(2025, 12, 18, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEventRepository)
+			service := NewEventService(mockRepo, new(MockTagRepository))
+
+			end := tt.start.AddDate(0, 0, 7).Add(-time.Nanosecond)
+			mockRepo.On("ListByUserAndDateRange", 1, tt.start, end, domain.PageRequest{}).Return(&domain.EventPage{}, nil)
+
+			_, err := service.GetEventsForCalendarWeek(context.Background(), 1, date, tt.weekStart, domain.PageRequest{})
+			assert.NoError(t, err)
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetEventsForRange(t *testing.T) {
+	from := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Успешное получение событий", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		service := NewEventService(mockRepo, new(MockTagRepository))
+
+		to := from.AddDate(0, 0, 10)
+		page := domain.PageRequest{Sort: domain.SortByUpdatedAt, Limit: 20}
+		mockRepo.On("ListByUserAndDateRange", 1, from, to.Add(-time.Nanosecond), page).Return(&domain.EventPage{}, nil)
+
+		_, err := service.GetEventsForRange(context.Background(), 1, from, to, page)
+		assert.NoError(t, err)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Слишком длинный период", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		service := NewEventService(mockRepo, new(MockTagRepository))
+
+		_, err := service.GetEventsForRange(context.Background(), 1, from, from.AddDate(2, 0, 0), domain.PageRequest{})
+		assert.Error(t, err)
+		appErr, ok := err.(*domain.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, domain.StatusBadRequest, appErr.GetStatusCode())
+	})
+}
+
+func TestGetEventsForYear(t *testing.T) {
+	mockRepo := new(MockEventRepository)
+	service := NewEventService(mockRepo, new(MockTagRepository))
+
+	year := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("GetByUserAndDateRange", 1, year, year.AddDate(1, 0, 0).Add(-time.Nanosecond)).Return([]*domain.Event{
+		{ID: 2, UserID: 1, Date: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)},
+		{ID: 1, UserID: 1, Date: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)},
+		{ID: 3, UserID: 1, Date: time.Date(2025, 12, 31, 10, 0, 0, 0, time.UTC)},
+	}, nil)
+
+	summary, err := service.GetEventsForYear(context.Background(), 1, year)
+	assert.NoError(t, err)
+	assert.Len(t, summary, 12)
+
+	assert.Equal(t, "2025-01", summary[0].Month)
+	assert.Equal(t, 2, summary[0].Count)
+	assert.Equal(t, 2, summary[0].Events[0].ID)
+	assert.Equal(t, 1, summary[0].Events[1].ID)
+	assert.Equal(t, 0, summary[5].Count)
+	assert.Equal(t, "2025-12", summary[11].Month)
+	assert.Equal(t, 1, summary[11].Count)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetAgenda(t *testing.T) {
+	mockRepo := new(MockEventRepository)
+	service := NewEventService(mockRepo, new(MockTagRepository))
+
+	from := time.Date(2025, 12, 18, 12, 0, 0, 0, time.UTC)
+	expected := &domain.EventPage{
+		Events:     []*domain.Event{{ID: 2, UserID: 1, Date: from.Add(time.Hour)}},
+		NextCursor: "next",
+	}
+	// Повестка всегда упорядочена по дате, даже если запрошен другой порядок
+	mockRepo.On("ListByUserAndDateRange", 1, from, mock.Anything, domain.PageRequest{Sort: domain.SortByDate, Limit: 1}).Return(expected, nil)
+
+	page, err := service.GetAgenda(context.Background(), 1, from, domain.PageRequest{Sort: domain.SortByUpdatedAt, Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, page)
+
+	_, err = service.GetAgenda(context.Background(), 1, from, domain.PageRequest{Limit: MaxAgendaLimit + 1})
+	assert.Error(t, err)
+
+	_, err = service.GetAgenda(context.Background(), 1, from, domain.PageRequest{})
+	assert.Error(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchEvents(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		limit       int
+		expectError bool
+	}{
+		{name: "Успешный поиск", query: "стоматолог", limit: 10},
+		{name: "Пустой запрос", query: "   ", limit: 10, expectError: true},
+		{name: "Слишком длинный запрос", query: strings.Repeat("я", MaxSearchQueryLength+1), limit: 10, expectError: true},
+		{name: "Некорректный limit", query: "стоматолог", limit: 0, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEventRepository)
+			service := NewEventService(mockRepo, new(MockTagRepository))
+
+			if !tt.expectError {
+				mockRepo.On("Search", 1, tt.query, tt.limit).Return([]*domain.SearchResult{}, nil)
+			}
+
+			_, err := service.SearchEvents(context.Background(), 1, tt.query, tt.limit)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				appErr, ok := err.(*domain.AppError)
+				assert.True(t, ok)
+				assert.Equal(t, domain.StatusBadRequest, appErr.GetStatusCode())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
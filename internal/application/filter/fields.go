@@ -0,0 +1,158 @@
+package filter
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"calendar/internal/domain"
+)
+
+// fieldKind - тип значения поля события
+type fieldKind int
+
+const (
+	kindText fieldKind = iota
+	kindTime
+	kindInt
+	kindTag
+)
+
+// field описывает поле события, доступное в фильтре
+type field struct {
+	kind fieldKind
+	text func(*domain.Event) string
+	time func(*domain.Event) time.Time
+	int  func(*domain.Event) int
+}
+
+// fields - поля, по которым можно фильтровать события
+var fields = map[string]field{
+	"id":       {kind: kindInt, int: func(e *domain.Event) int { return e.ID }},
+	"text":     {kind: kindText, text: func(e *domain.Event) string { return e.Text }},
+	"title":    {kind: kindText, text: func(e *domain.Event) string { return e.Title }},
+	"location": {kind: kindText, text: func(e *domain.Event) string { return e.LocationText() }},
+	"tag":      {kind: kindTag},
+	"date":     {kind: kindTime, time: func(e *domain.Event) time.Time { return e.Date }},
+	"end":      {kind: kindTime, time: func(e *domain.Event) time.Time { return e.End() }},
+	"created":  {kind: kindTime, time: func(e *domain.Event) time.Time { return e.CreatedAt }},
+	"updated":  {kind: kindTime, time: func(e *domain.Event) time.Time { return e.UpdatedAt }},
+}
+
+// timeLayouts - допустимые форматы значений времени; первый без времени суток означает весь день
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
+// dayLayout - формат значения, обозначающего целый день
+const dayLayout = "2006-01-02"
+
+// matcher проверяет событие на соответствие условию
+type matcher func(*domain.Event) bool
+
+// compare строит условие для поля, оператора и значения
+func (f field) compare(op, value token, loc *time.Location) (matcher, error) {
+	switch f.kind {
+	case kindText:
+		return f.compareText(op, value)
+	case kindTime:
+		return f.compareTime(op, value, loc)
+	case kindTag:
+		return f.compareTag(op, value)
+	default:
+		return f.compareInt(op, value)
+	}
+}
+
+// compareText: ":" - содержит подстроку, "=" и "!=" - равенство без учета регистра
+func (f field) compareText(op, value token) (matcher, error) {
+	needle := strings.ToLower(value.text)
+	switch op.text {
+	case ":":
+		return func(e *domain.Event) bool { return strings.Contains(strings.ToLower(f.text(e)), needle) }, nil
+	case "=":
+		return func(e *domain.Event) bool { return strings.EqualFold(f.text(e), value.text) }, nil
+	case "!=":
+		return func(e *domain.Event) bool { return !strings.EqualFold(f.text(e), value.text) }, nil
+	}
+	return nil, unsupportedOperator(op)
+}
+
+// compareTag: ":" и "=" - событие отмечено тегом, "!=" - не отмечено
+func (f field) compareTag(op, value token) (matcher, error) {
+	switch op.text {
+	case ":", "=":
+		return func(e *domain.Event) bool { return e.HasTag(value.text) }, nil
+	case "!=":
+		return func(e *domain.Event) bool { return !e.HasTag(value.text) }, nil
+	}
+	return nil, unsupportedOperator(op)
+}
+
+// compareTime сравнивает время; значение без времени суток обозначает весь день целиком,
+// поэтому updated>2025-01-01 означает "после 1 января", а date:2025-01-01 - "в течение 1 января"
+func (f field) compareTime(op, value token, loc *time.Location) (matcher, error) {
+	start, end, err := parseTimeValue(value, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	inRange := func(t time.Time) bool { return !t.Before(start) && t.Before(end) }
+	switch op.text {
+	case ":", "=":
+		return func(e *domain.Event) bool { return inRange(f.time(e)) }, nil
+	case "!=":
+		return func(e *domain.Event) bool { return !inRange(f.time(e)) }, nil
+	case ">":
+		return func(e *domain.Event) bool { return !f.time(e).Before(end) }, nil
+	case ">=":
+		return func(e *domain.Event) bool { return !f.time(e).Before(start) }, nil
+	case "<":
+		return func(e *domain.Event) bool { return f.time(e).Before(start) }, nil
+	case "<=":
+		return func(e *domain.Event) bool { return f.time(e).Before(end) }, nil
+	}
+	return nil, unsupportedOperator(op)
+}
+
+// compareInt сравнивает целые числа
+func (f field) compareInt(op, value token) (matcher, error) {
+	n, err := strconv.Atoi(value.text)
+	if err != nil {
+		return nil, syntaxError(value.pos, "ожидалось целое число, получено \""+value.text+"\"", domain.MsgFilterExpectedInteger, domain.MessageParams{"token": value.text})
+	}
+
+	switch op.text {
+	case ":", "=":
+		return func(e *domain.Event) bool { return f.int(e) == n }, nil
+	case "!=":
+		return func(e *domain.Event) bool { return f.int(e) != n }, nil
+	case ">":
+		return func(e *domain.Event) bool { return f.int(e) > n }, nil
+	case ">=":
+		return func(e *domain.Event) bool { return f.int(e) >= n }, nil
+	case "<":
+		return func(e *domain.Event) bool { return f.int(e) < n }, nil
+	case "<=":
+		return func(e *domain.Event) bool { return f.int(e) <= n }, nil
+	}
+	return nil, unsupportedOperator(op)
+}
+
+// parseTimeValue возвращает полуинтервал [start, end), обозначаемый значением времени
+func parseTimeValue(value token, loc *time.Location) (time.Time, time.Time, error) {
+	if day, err := time.ParseInLocation(dayLayout, value.text, loc); err == nil {
+		return day, day.AddDate(0, 0, 1), nil
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value.text, loc); err == nil {
+			return t, t.Add(time.Nanosecond), nil
+		}
+	}
+
+	return time.Time{}, time.Time{}, syntaxError(value.pos, "некорректная дата \""+value.text+"\", используйте YYYY-MM-DD или YYYY-MM-DDTHH:MM", domain.MsgFilterInvalidDate,
+		domain.MessageParams{"token": value.text})
+}
@@ -0,0 +1,144 @@
+package filter
+
+import (
+	"strings"
+	"unicode"
+
+	"calendar/internal/domain"
+)
+
+// tokenKind - тип лексемы фильтра
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenLParen
+	tokenRParen
+	tokenWord
+	tokenString
+	tokenOperator
+)
+
+// token - лексема с позицией (номер символа, начиная с 1) во входной строке
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// isKeyword проверяет, является ли лексема ключевым словом (без учета регистра)
+func (t token) isKeyword(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+// lexer разбивает выражение фильтра на лексемы
+type lexer struct {
+	input []rune
+	pos   int
+}
+
+// isOperatorRune проверяет, может ли символ начинать оператор сравнения
+func isOperatorRune(r rune) bool {
+	return r == ':' || r == '=' || r == '!' || r == '<' || r == '>'
+}
+
+// skipSpace пропускает пробельные символы
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.input) && unicode.IsSpace(l.input[l.pos]) {
+		l.pos++
+	}
+}
+
+// next возвращает следующую лексему: скобку, оператор или слово
+func (l *lexer) next() (token, error) {
+	l.skipSpace()
+	start := l.pos
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, pos: start + 1}, nil
+	}
+
+	switch r := l.input[l.pos]; {
+	case r == '(':
+		l.pos++
+		return token{kind: tokenLParen, text: "(", pos: start + 1}, nil
+	case r == ')':
+		l.pos++
+		return token{kind: tokenRParen, text: ")", pos: start + 1}, nil
+	case r == '"':
+		return l.quoted()
+	case isOperatorRune(r):
+		return l.operator()
+	}
+
+	for l.pos < len(l.input) {
+		r := l.input[l.pos]
+		if unicode.IsSpace(r) || r == '(' || r == ')' || r == '"' || isOperatorRune(r) {
+			break
+		}
+		l.pos++
+	}
+	return token{kind: tokenWord, text: string(l.input[start:l.pos]), pos: start + 1}, nil
+}
+
+// nextValue возвращает значение после оператора: строку в кавычках или слово до пробела или скобки.
+// В отличие от next, двоеточия и прочие символы операторов входят в значение (например, во время 10:30).
+func (l *lexer) nextValue() (token, error) {
+	l.skipSpace()
+	start := l.pos
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, pos: start + 1}, nil
+	}
+	switch l.input[l.pos] {
+	case '"':
+		return l.quoted()
+	case '(', ')':
+		return l.next()
+	}
+
+	for l.pos < len(l.input) {
+		r := l.input[l.pos]
+		if unicode.IsSpace(r) || r == '(' || r == ')' {
+			break
+		}
+		l.pos++
+	}
+	return token{kind: tokenWord, text: string(l.input[start:l.pos]), pos: start + 1}, nil
+}
+
+// operator читает оператор сравнения
+func (l *lexer) operator() (token, error) {
+	start := l.pos
+	r := l.input[l.pos]
+	l.pos++
+
+	if l.pos < len(l.input) && l.input[l.pos] == '=' && (r == '!' || r == '<' || r == '>') {
+		l.pos++
+	} else if r == '!' {
+		return token{}, syntaxError(start+1, "ожидался оператор !=", domain.MsgFilterExpectedNotEqual, nil)
+	}
+
+	return token{kind: tokenOperator, text: string(l.input[start:l.pos]), pos: start + 1}, nil
+}
+
+// quoted читает строку в двойных кавычках; внутри допускаются экранированные \" и \\
+func (l *lexer) quoted() (token, error) {
+	start := l.pos
+	l.pos++
+
+	var b strings.Builder
+	for l.pos < len(l.input) {
+		r := l.input[l.pos]
+		l.pos++
+		switch {
+		case r == '"':
+			return token{kind: tokenString, text: b.String(), pos: start + 1}, nil
+		case r == '\\' && l.pos < len(l.input):
+			b.WriteRune(l.input[l.pos])
+			l.pos++
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return token{}, syntaxError(start+1, "незакрытая кавычка", domain.MsgFilterUnclosedQuote, nil)
+}
@@ -0,0 +1,321 @@
+// Package filter реализует язык фильтрации событий вида
+//
+//	text:врач AND (date>=2025-01-01 OR NOT updated<2025-06-01)
+//
+// Выражение состоит из сравнений поле-оператор-значение, объединенных AND, OR, NOT и скобками.
+// Операторы: ":" (для текста - содержит, для дат - в течение дня), "=", "!=", ">", ">=", "<", "<=".
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"calendar/internal/domain"
+)
+
+// MaxLength - максимальная длина выражения фильтра в символах
+const MaxLength = 1000
+
+// Filter - разобранное выражение фильтра
+type Filter struct {
+	source string
+	root   node
+}
+
+// Match проверяет событие на соответствие фильтру
+func (f *Filter) Match(event *domain.Event) bool {
+	return f.root.match(event)
+}
+
+// String возвращает исходное выражение фильтра
+func (f *Filter) String() string {
+	return f.source
+}
+
+// HasTag возвращает фильтр событий, отмеченных хотя бы одним из тегов
+func HasTag(names ...string) domain.EventFilter {
+	return matchFunc(func(e *domain.Event) bool {
+		for _, name := range names {
+			if e.HasTag(name) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// All объединяет фильтры условием AND, пропуская nil
+func All(filters ...domain.EventFilter) domain.EventFilter {
+	var active []domain.EventFilter
+	for _, f := range filters {
+		if f != nil {
+			active = append(active, f)
+		}
+	}
+	if len(active) == 1 {
+		return active[0]
+	}
+
+	return matchFunc(func(e *domain.Event) bool {
+		for _, f := range active {
+			if !f.Match(e) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// matchFunc позволяет использовать функцию как domain.EventFilter
+type matchFunc func(*domain.Event) bool
+
+// Match проверяет событие на соответствие условию
+func (f matchFunc) Match(e *domain.Event) bool { return f(e) }
+
+// node - узел синтаксического дерева фильтра
+type node interface {
+	match(event *domain.Event) bool
+}
+
+type andNode struct{ left, right node }
+type orNode struct{ left, right node }
+type notNode struct{ operand node }
+type comparisonNode struct {
+	field string
+	op    string
+	value string
+	test  matcher
+}
+
+func (n *andNode) match(e *domain.Event) bool { return n.left.match(e) && n.right.match(e) }
+func (n *orNode) match(e *domain.Event) bool  { return n.left.match(e) || n.right.match(e) }
+func (n *notNode) match(e *domain.Event) bool { return !n.operand.match(e) }
+
+func (n *comparisonNode) match(e *domain.Event) bool { return n.test(e) }
+
+// Parse разбирает выражение фильтра; значения дат без смещения интерпретируются в поясе loc
+func Parse(input string, loc *time.Location) (*Filter, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, domain.NewFieldError("filter", domain.CodeInvalidFilter, "фильтр не может быть пустым").
+			WithDetails(domain.MsgFilterEmpty, nil)
+	}
+	if len([]rune(input)) > MaxLength {
+		return nil, domain.NewFieldError("filter", domain.CodeInvalidFilter, fmt.Sprintf("фильтр длиннее %d символов", MaxLength)).
+			WithDetails(domain.MsgFilterTooLong, domain.MessageParams{"max": strconv.Itoa(MaxLength)})
+	}
+
+	p := &parser{lex: &lexer{input: []rune(input)}, loc: loc}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	tok, err := p.peek()
+	if err != nil {
+		return nil, err
+	}
+	if tok.kind != tokenEOF {
+		return nil, syntaxError(tok.pos, "неожиданная лексема \""+tok.text+"\"", domain.MsgFilterUnexpectedToken, domain.MessageParams{"token": tok.text})
+	}
+
+	return &Filter{source: input, root: root}, nil
+}
+
+// parser - рекурсивный нисходящий разбор с просмотром на одну лексему вперед
+type parser struct {
+	lex    *lexer
+	loc    *time.Location
+	peeked *token
+}
+
+// peek возвращает следующую лексему, не потребляя ее
+func (p *parser) peek() (token, error) {
+	if p.peeked == nil {
+		tok, err := p.lex.next()
+		if err != nil {
+			return token{}, err
+		}
+		p.peeked = &tok
+	}
+	return *p.peeked, nil
+}
+
+// consume потребляет следующую лексему
+func (p *parser) consume() (token, error) {
+	tok, err := p.peek()
+	p.peeked = nil
+	return tok, err
+}
+
+// parseOr: and ("OR" and)*
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok, err := p.peek()
+		if err != nil {
+			return nil, err
+		}
+		if !tok.isKeyword("OR") {
+			return left, nil
+		}
+		p.consume()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+}
+
+// parseAnd: not ("AND" not)*
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok, err := p.peek()
+		if err != nil {
+			return nil, err
+		}
+		if !tok.isKeyword("AND") {
+			return left, nil
+		}
+		p.consume()
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+}
+
+// parseNot: "NOT" not | primary
+func (p *parser) parseNot() (node, error) {
+	tok, err := p.peek()
+	if err != nil {
+		return nil, err
+	}
+	if !tok.isKeyword("NOT") {
+		return p.parsePrimary()
+	}
+	p.consume()
+
+	operand, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	return &notNode{operand: operand}, nil
+}
+
+// parsePrimary: "(" or ")" | comparison
+func (p *parser) parsePrimary() (node, error) {
+	tok, err := p.consume()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case tok.kind == tokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing, err := p.consume()
+		if err != nil {
+			return nil, err
+		}
+		if closing.kind != tokenRParen {
+			return nil, expected(closing, "ожидалась закрывающая скобка", domain.MsgFilterExpectedParen, nil)
+		}
+		return inner, nil
+	case tok.kind == tokenWord && !tok.isKeyword("AND") && !tok.isKeyword("OR"):
+		return p.parseComparison(tok)
+	case tok.kind == tokenEOF:
+		return nil, syntaxError(tok.pos, "неожиданный конец фильтра, ожидалось условие", domain.MsgFilterUnexpectedEnd, nil)
+	}
+
+	return nil, expected(tok, "ожидалось условие", domain.MsgFilterExpectedCondition, nil)
+}
+
+// parseComparison: поле оператор значение
+func (p *parser) parseComparison(name token) (node, error) {
+	f, ok := fields[strings.ToLower(name.text)]
+	if !ok {
+		return nil, syntaxError(name.pos, "неизвестное поле \""+name.text+"\", доступны: "+fieldNames(), domain.MsgFilterUnknownField,
+			domain.MessageParams{"token": name.text, "fields": fieldNames()})
+	}
+
+	op, err := p.consume()
+	if err != nil {
+		return nil, err
+	}
+	if op.kind != tokenOperator {
+		return nil, expected(op, "ожидался оператор сравнения после поля \""+name.text+"\"", domain.MsgFilterExpectedOperator, domain.MessageParams{"name": name.text})
+	}
+
+	value, err := p.lex.nextValue()
+	if err != nil {
+		return nil, err
+	}
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, expected(value, "ожидалось значение после оператора \""+op.text+"\"", domain.MsgFilterExpectedValue, domain.MessageParams{"operator": op.text})
+	}
+
+	m, err := f.compare(op, value, p.loc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &comparisonNode{field: strings.ToLower(name.text), op: op.text, value: value.text, test: m}, nil
+}
+
+// expected возвращает ошибку "ожидалось ..." с описанием встреченной лексемы tok;
+// если фильтр закончился, ошибка сообщает о неожиданном конце фильтра
+func expected(tok token, message string, key domain.MessageKey, params domain.MessageParams) error {
+	if tok.kind == tokenEOF {
+		return syntaxError(tok.pos, message+", а фильтр закончился", domain.MsgFilterUnexpectedEnd, nil)
+	}
+
+	withToken := domain.MessageParams{"token": tok.text}
+	for name, value := range params {
+		withToken[name] = value
+	}
+	return syntaxError(tok.pos, message+", получено \""+tok.text+"\"", key, withToken)
+}
+
+// fieldNames возвращает список доступных полей через запятую
+func fieldNames() string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// unsupportedOperator возвращает ошибку неподдерживаемого для поля оператора
+func unsupportedOperator(op token) error {
+	return syntaxError(op.pos, "оператор \""+op.text+"\" не поддерживается для этого поля", domain.MsgFilterUnsupportedOp, domain.MessageParams{"operator": op.text})
+}
+
+// syntaxError возвращает ошибку валидации с позицией в выражении фильтра. Ключ key и параметры
+// params сохраняют подробности ошибки для перевода; позиция добавляется к параметрам сама
+func syntaxError(pos int, message string, key domain.MessageKey, params domain.MessageParams) error {
+	withPosition := domain.MessageParams{"position": strconv.Itoa(pos)}
+	for name, value := range params {
+		withPosition[name] = value
+	}
+	return domain.NewFieldError("filter", domain.CodeInvalidFilter, fmt.Sprintf("ошибка в фильтре на позиции %d: %s", pos, message)).
+		WithDetails(key, withPosition)
+}
@@ -0,0 +1,107 @@
+package filter
+
+import (
+	"calendar/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_Match(t *testing.T) {
+	event := &domain.Event{
+		ID:        7,
+		UserID:    1,
+		Date:      time.Date(2025, 12, 18, 10, 30, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 12, 18, 11, 30, 0, 0, time.UTC),
+		Text:      "Прием у Стоматолога",
+		Title:     "Стоматолог",
+		Location:  &domain.Location{Text: "Клиника на Арбате"},
+		Tags:      []string{"Здоровье", "personal"},
+		CreatedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		expr  string
+		match bool
+	}{
+		{expr: "text:стоматолог", match: true},
+		{expr: `text="прием у стоматолога"`, match: true},
+		{expr: "text!=прием", match: true},
+		{expr: "date:2025-12-18", match: true},
+		{expr: "date:2025-12-19", match: false},
+		{expr: "date>=2025-12-18T10:30", match: true},
+		{expr: "date>2025-12-18T10:30", match: false},
+		{expr: "end<=2025-12-18", match: true},
+		{expr: "updated>2025-01-01", match: true},
+		{expr: "created>2025-01-01", match: false},
+		{expr: "created>=2025-01-01", match: true},
+		{expr: "id=7 AND text:прием", match: true},
+		{expr: "id>7 OR text:врач", match: false},
+		{expr: "NOT id<7", match: true},
+		{expr: "not (id=1 or id=2) and updated<2025-07-01", match: true},
+		{expr: "id=1 OR id=7 AND text:врач", match: false},
+		{expr: "(id=1 OR id=7) AND text:прием", match: true},
+		{expr: "tag:здоровье", match: true},
+		{expr: "title=стоматолог AND location:арбат", match: true},
+		{expr: "location:тверская", match: false},
+		{expr: "tag:work", match: false},
+		{expr: "tag!=work AND tag=PERSONAL", match: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := Parse(tt.expr, time.UTC)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.match, f.Match(event))
+		})
+	}
+}
+
+func TestParse_TimeZone(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	assert.NoError(t, err)
+
+	// 22:00 UTC 17 декабря - это уже 18 декабря по Москве
+	event := &domain.Event{Date: time.Date(2025, 12, 17, 22, 0, 0, 0, time.UTC)}
+
+	f, err := Parse("date:2025-12-18", moscow)
+	assert.NoError(t, err)
+	assert.True(t, f.Match(event))
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		message string
+	}{
+		{expr: "", message: "фильтр не может быть пустым"},
+		{expr: "label:work", message: "позиции 1: неизвестное поле \"label\""},
+		{expr: "tag>work", message: "позиции 4: оператор \">\" не поддерживается"},
+		{expr: "text:врач AND updatd>2025-01-01", message: "позиции 15: неизвестное поле \"updatd\""},
+		{expr: "updated>2025-13-01", message: "позиции 9: некорректная дата \"2025-13-01\""},
+		{expr: "id:seven", message: "позиции 4: ожидалось целое число"},
+		{expr: "text>врач", message: "позиции 5: оператор \">\" не поддерживается"},
+		{expr: "text врач", message: "позиции 6: ожидался оператор сравнения после поля \"text\""},
+		{expr: "text:", message: "позиции 6: ожидалось значение после оператора \":\", а фильтр закончился"},
+		{expr: "(id=1 OR id=2", message: "позиции 14: ожидалась закрывающая скобка, а фильтр закончился"},
+		{expr: "id=1 id=2", message: "позиции 6: неожиданная лексема \"id\""},
+		{expr: "id=1 AND", message: "позиции 9: неожиданный конец фильтра"},
+		{expr: "text:\"врач", message: "позиции 6: незакрытая кавычка"},
+		{expr: "id!1", message: "позиции 3: ожидался оператор !="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr, time.UTC)
+			assert.Error(t, err)
+			appErr, ok := err.(*domain.AppError)
+			assert.True(t, ok)
+			assert.Equal(t, domain.StatusBadRequest, appErr.GetStatusCode())
+			assert.Equal(t, domain.CodeInvalidFilter, appErr.ErrorCode())
+			assert.Equal(t, "filter", appErr.Fields[0].Field)
+			assert.Contains(t, appErr.Error(), tt.message)
+		})
+	}
+}
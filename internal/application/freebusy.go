@@ -0,0 +1,81 @@
+package application
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"sort"
+	"time"
+)
+
+const (
+	// MaxFreeBusyUsers - максимальное количество пользователей в одном запросе занятости
+	MaxFreeBusyUsers = 50
+	// MaxFreeBusySpan - максимальная длина периода запроса занятости
+	MaxFreeBusySpan = 62 * 24 * time.Hour
+)
+
+// GetFreeBusy возвращает объединенные интервалы занятости пользователей в периоде [from, to).
+// Содержимое событий не раскрывается: наружу попадают только границы интервалов.
+func (s *EventService) GetFreeBusy(ctx context.Context, userIDs []int, from, to time.Time) ([]*domain.FreeBusy, error) {
+	ctx, span := tracer.Start(ctx, "EventService.GetFreeBusy")
+	defer span.End()
+
+	if err := s.validator.ValidateUserIDs(userIDs); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.ValidateTimeRange(from, to, MaxFreeBusySpan); err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.FreeBusy, 0, len(userIDs))
+	for _, userID := range userIDs {
+		// Длительность событий не ограничена, поэтому выбираем по пересечению, а не по дате начала
+		events, err := s.repo.GetOverlapping(ctx, userID, from, to)
+		if err != nil {
+			return nil, domain.NewInternalError("ошибка при получении событий", err)
+		}
+
+		result = append(result, &domain.FreeBusy{
+			UserID: userID,
+			Busy:   coalesceBusy(events, from, to),
+		})
+	}
+
+	return result, nil
+}
+
+// coalesceBusy обрезает события по границам периода и объединяет пересекающиеся интервалы
+func coalesceBusy(events []*domain.Event, from, to time.Time) []domain.TimePeriod {
+	periods := make([]domain.TimePeriod, 0, len(events))
+	for _, event := range events {
+		if !event.Overlaps(from, to) {
+			continue
+		}
+		start, end := event.Date, event.End()
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		periods = append(periods, domain.TimePeriod{Start: start, End: end})
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].Start.Before(periods[j].Start)
+	})
+
+	busy := make([]domain.TimePeriod, 0, len(periods))
+	for _, p := range periods {
+		if n := len(busy); n > 0 && !p.Start.After(busy[n-1].End) {
+			if p.End.After(busy[n-1].End) {
+				busy[n-1].End = p.End
+			}
+			continue
+		}
+		busy = append(busy, p)
+	}
+
+	return busy
+}
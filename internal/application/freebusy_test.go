@@ -0,0 +1,80 @@
+package application
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetFreeBusy(t *testing.T) {
+	from := time.Date(2025, 12, 18, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)
+	at := func(hour, min int) time.Time {
+		return time.Date(2025, 12, 18, hour, min, 0, 0, time.UTC)
+	}
+
+	mockRepo := new(MockEventRepository)
+	service := NewEventService(mockRepo, new(MockTagRepository))
+
+	mockRepo.On("GetOverlapping", 1, from, to).Return([]*domain.Event{
+		{ID: 2, UserID: 1, Date: at(10, 30), EndDate: at(12, 0), Text: "Секрет 2"},
+		{ID: 1, UserID: 1, Date: at(9, 0), EndDate: at(11, 0), Text: "Секрет 1"},
+		{ID: 3, UserID: 1, Date: at(12, 0), EndDate: at(13, 0), Text: "Секрет 3"},
+		{ID: 4, UserID: 1, Date: at(15, 0), EndDate: at(16, 0), Text: "Секрет 4"},
+		// Событие прошлого дня без даты окончания заканчивается внутри периода
+		{ID: 5, UserID: 1, Date: from.Add(-20 * time.Hour), Text: "Вчера"},
+		// Многодневное событие, начавшееся раньше чем за сутки до периода
+		{ID: 6, UserID: 1, Date: from.Add(-72 * time.Hour), EndDate: at(1, 0), Text: "Командировка"},
+	}, nil)
+	mockRepo.On("GetOverlapping", 2, from, to).Return([]*domain.Event{}, nil)
+
+	result, err := service.GetFreeBusy(context.Background(), []int{1, 2}, from, to)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	assert.Equal(t, 1, result[0].UserID)
+	assert.Equal(t, []domain.TimePeriod{
+		{Start: from, End: at(4, 0)},
+		{Start: at(9, 0), End: at(13, 0)},
+		{Start: at(15, 0), End: at(16, 0)},
+	}, result[0].Busy)
+
+	assert.Equal(t, 2, result[1].UserID)
+	assert.Empty(t, result[1].Busy)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetFreeBusy_Validation(t *testing.T) {
+	from := time.Date(2025, 12, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		userIDs []int
+		from    time.Time
+		to      time.Time
+	}{
+		{name: "Пустой список пользователей", userIDs: nil, from: from, to: from.Add(time.Hour)},
+		{name: "Некорректный user_id", userIDs: []int{1, 0}, from: from, to: from.Add(time.Hour)},
+		{name: "Конец раньше начала", userIDs: []int{1}, from: from, to: from.Add(-time.Hour)},
+		{name: "Слишком длинный период", userIDs: []int{1}, from: from, to: from.Add(MaxFreeBusySpan + time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEventRepository)
+			service := NewEventService(mockRepo, new(MockTagRepository))
+
+			_, err := service.GetFreeBusy(context.Background(), tt.userIDs, tt.from, tt.to)
+			assert.Error(t, err)
+			appErr, ok := err.(*domain.AppError)
+			assert.True(t, ok)
+			assert.Equal(t, domain.StatusBadRequest, appErr.GetStatusCode())
+			mockRepo.AssertNotCalled(t, "GetByUserAndDateRange", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
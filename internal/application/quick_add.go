@@ -0,0 +1,62 @@
+package application
+
+import (
+	"calendar/internal/application/quickadd"
+	"calendar/internal/domain"
+	"context"
+	"time"
+)
+
+// QuickAdd разбирает фразу на естественном языке в событие относительно текущего времени в часовом поясе loc.
+// Без save событие не сохраняется: возвращается интерпретация и события, с которыми оно пересеклось бы.
+func (s *EventService) QuickAdd(ctx context.Context, userID int, phrase string, loc *time.Location, save bool, policy domain.ConflictPolicy) (*domain.QuickAddResult, []*domain.Event, error) {
+	ctx, span := tracer.Start(ctx, "EventService.QuickAdd")
+	defer span.End()
+
+	if err := s.validator.ValidateUserID(userID); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.validator.ValidateConflictPolicy(policy); err != nil {
+		return nil, nil, err
+	}
+
+	parsed, err := quickadd.Parse(phrase, s.now().In(loc))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := &domain.QuickAddResult{
+		AllDay:     parsed.AllDay,
+		Recognized: parsed.Recognized,
+	}
+	input := domain.EventInput{
+		Date:    parsed.Start,
+		EndDate: parsed.End,
+		Text:    parsed.Text,
+	}
+
+	if save {
+		event, conflicts, err := s.CreateEvent(ctx, userID, input, policy)
+		if err != nil {
+			return nil, nil, err
+		}
+		result.Event = event
+		result.Saved = true
+		return result, conflicts, nil
+	}
+
+	result.Event = &domain.Event{
+		UserID:  userID,
+		Date:    input.Date,
+		EndDate: input.EndDate,
+		Text:    input.Text,
+	}
+
+	conflicts, err := s.checkConflicts(ctx, result.Event, domain.ConflictWarn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, conflicts, nil
+}
@@ -0,0 +1,64 @@
+package application
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQuickAdd(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	assert.NoError(t, err)
+
+	now := time.Now().In(moscow)
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, moscow)
+
+	t.Run("Интерпретация без сохранения", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		service := NewEventService(mockRepo, new(MockTagRepository))
+
+		existing := &domain.Event{ID: 5, UserID: 1, Date: tomorrow.Add(30 * time.Minute), EndDate: tomorrow.Add(90 * time.Minute), Text: "Планерка"}
+		mockRepo.On("GetOverlapping", 1, mock.Anything, mock.Anything).Return([]*domain.Event{existing}, nil)
+
+		result, conflicts, err := service.QuickAdd(context.Background(), 1, "встреча завтра в 10", moscow, false, domain.ConflictWarn)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Saved)
+		assert.Equal(t, "встреча", result.Event.Text)
+		assert.True(t, tomorrow.Equal(result.Event.Date))
+		assert.True(t, tomorrow.Add(time.Hour).Equal(result.Event.EndDate))
+		assert.Equal(t, []string{"завтра", "в 10"}, result.Recognized)
+		assert.Equal(t, []*domain.Event{existing}, conflicts)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Сохранение", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		service := NewEventService(mockRepo, new(MockTagRepository))
+
+		mockRepo.On("Create", mock.AnythingOfType("*domain.Event")).Return(nil)
+
+		result, _, err := service.QuickAdd(context.Background(), 1, "lunch tomorrow at 10:00 for 30m", moscow, true, domain.ConflictAllow)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Saved)
+		assert.Equal(t, "lunch", result.Event.Text)
+		assert.True(t, tomorrow.Add(30*time.Minute).Equal(result.Event.EndDate))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Фраза без даты", func(t *testing.T) {
+		mockRepo := new(MockEventRepository)
+		service := NewEventService(mockRepo, new(MockTagRepository))
+
+		_, _, err := service.QuickAdd(context.Background(), 1, "просто заметка", moscow, true, domain.ConflictAllow)
+
+		assert.Error(t, err)
+		assert.Equal(t, domain.StatusBadRequest, err.(*domain.AppError).GetStatusCode())
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
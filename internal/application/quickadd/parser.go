@@ -0,0 +1,590 @@
+// Package quickadd разбирает короткие фразы на английском и русском языках в черновик события, например
+//
+//	lunch with Anna tomorrow at 13:00 for 1h
+//	встреча в пятницу в 10
+//
+// Из фразы выделяются день (today, завтра, in 3 days, в пятницу, 18 декабря, 2025-12-18), время начала
+// (at 1pm, в 10 утра, noon), интервал (from 10 to 11, с 10 до 11:30) и длительность (for 1h, на полчаса).
+// Оставшиеся слова становятся текстом события. Все значения вычисляются относительно переданного
+// момента времени и в его часовом поясе.
+package quickadd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"calendar/internal/domain"
+)
+
+const (
+	// MaxLength - максимальная длина фразы в символах
+	MaxLength = 500
+	// DefaultDuration - длительность события с временем начала, но без окончания и длительности
+	DefaultDuration = time.Hour
+)
+
+var (
+	// clockPattern - время вида 13, 13:00, 1pm, 1:30pm
+	clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	// numericDatePattern - дата вида 18.12 или 18.12.2025
+	numericDatePattern = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})(?:\.(\d{4}))?$`)
+	// dayNumberPattern - число месяца, в том числе с английским суффиксом: 18, 18th
+	dayNumberPattern = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+	// compactDurationPattern - длительность одним словом: 1h, 30m, 1h30m, 1.5ч, 45мин
+	compactDurationPattern = regexp.MustCompile(`^(?:(\d+(?:\.\d+)?)(?:h|hr|hrs|ч|час|часа))?(?:(\d+)(?:m|min|mins|м|мин))?$`)
+)
+
+// Result - интерпретация фразы
+type Result struct {
+	// Text - текст события: слова фразы, не относящиеся к дате и времени
+	Text string
+	// Start - начало события; для события на весь день - полночь
+	Start time.Time
+	// End - окончание события; пустое для события на весь день
+	End time.Time
+	// AllDay - время начала не указано, событие длится весь день
+	AllDay bool
+	// Recognized - фрагменты фразы, распознанные как дата, время или длительность
+	Recognized []string
+}
+
+// clock - время суток
+type clock struct {
+	hour, minute int
+	// explicit - время записано однозначно (с минутами или am/pm), а не одним числом
+	explicit bool
+	// meridiem - указано время суток: am/pm, утра/вечера
+	meridiem bool
+}
+
+// parser хранит состояние разбора фразы
+type parser struct {
+	now   time.Time
+	words []string // исходные слова
+	norm  []string // слова в нижнем регистре без знаков препинания по краям
+
+	hasDay           bool
+	year             int
+	month            time.Month
+	day              int
+	start, end       *clock
+	duration         time.Duration
+	allDay           bool
+	text, recognized []string
+	relative         *time.Time
+}
+
+// Parse разбирает фразу относительно момента now
+func Parse(phrase string, now time.Time) (*Result, error) {
+	phrase = strings.TrimSpace(phrase)
+	if phrase == "" {
+		return nil, domain.NewFieldError("text", domain.CodeInvalidPhrase, "фраза не может быть пустой")
+	}
+	if len([]rune(phrase)) > MaxLength {
+		return nil, domain.NewFieldError("text", domain.CodeInvalidPhrase, "фраза слишком длинная")
+	}
+
+	p := &parser{now: now, words: strings.Fields(phrase)}
+	for _, word := range p.words {
+		p.norm = append(p.norm, normalize(word))
+	}
+
+	for i := 0; i < len(p.words); {
+		if n := p.match(i); n > 0 {
+			p.recognized = append(p.recognized, strings.Join(p.words[i:i+n], " "))
+			i += n
+			continue
+		}
+		p.text = append(p.text, p.words[i])
+		i++
+	}
+
+	return p.result()
+}
+
+// normalize приводит слово к нижнему регистру и отбрасывает знаки препинания по краям
+func normalize(word string) string {
+	word = strings.ToLower(strings.ReplaceAll(word, "ё", "е"))
+	if word == "-" {
+		return word
+	}
+	return strings.Trim(word, ",.!?;:()\"'«»")
+}
+
+// word возвращает нормализованное слово с индексом i или пустую строку
+func (p *parser) word(i int) string {
+	if i < 0 || i >= len(p.norm) {
+		return ""
+	}
+	return p.norm[i]
+}
+
+// match пытается распознать фрагмент, начинающийся со слова i, и возвращает число его слов
+func (p *parser) match(i int) int {
+	for _, rule := range []func(int) int{
+		p.matchAllDay,
+		p.matchRange,
+		p.matchDuration,
+		p.matchRelative,
+		p.matchDate,
+		p.matchWeekday,
+		p.matchClock,
+	} {
+		if n := rule(i); n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+// setDay запоминает день, отстоящий от сегодняшнего на offset дней
+func (p *parser) setDay(offset int) {
+	day := time.Date(p.now.Year(), p.now.Month(), p.now.Day()+offset, 0, 0, 0, 0, p.now.Location())
+	p.hasDay = true
+	p.year, p.month, p.day = day.Date()
+}
+
+// matchAllDay распознает "all day" и "весь день"
+func (p *parser) matchAllDay(i int) int {
+	if p.allDay || p.start != nil {
+		return 0
+	}
+	if pair := p.word(i) + " " + p.word(i+1); pair == "all day" || pair == "весь день" || pair == "целый день" {
+		p.allDay = true
+		return 2
+	}
+	return 0
+}
+
+// matchRelative распознает относительные дни и сдвиги: today, завтра, day after tomorrow,
+// in 3 days, через неделю, in 2 hours, через полчаса
+func (p *parser) matchRelative(i int) int {
+	if p.hasDay || p.relative != nil {
+		return 0
+	}
+
+	switch p.word(i) {
+	case "today", "сегодня":
+		p.setDay(0)
+		return 1
+	case "tomorrow", "завтра":
+		p.setDay(1)
+		return 1
+	case "послезавтра":
+		p.setDay(2)
+		return 1
+	case "day":
+		if p.word(i+1) == "after" && p.word(i+2) == "tomorrow" {
+			p.setDay(2)
+			return 3
+		}
+		return 0
+	}
+
+	if !relativePrefixes[p.word(i)] {
+		return 0
+	}
+
+	shift, n := p.parseAmount(i + 1)
+	if n == 0 {
+		return 0
+	}
+
+	if shift%(24*time.Hour) == 0 {
+		p.setDay(int(shift / (24 * time.Hour)))
+		return n + 1
+	}
+
+	// Сдвиг в часах и минутах задает точное время начала
+	if p.start != nil {
+		return 0
+	}
+	at := p.now.Add(shift).Truncate(time.Minute)
+	p.relative = &at
+	return n + 1
+}
+
+// parseAmount распознает количество с единицей измерения, начиная со слова i:
+// 2 hours, an hour, 1.5 часа, час, полчаса, half an hour, 1h30m, 45мин
+func (p *parser) parseAmount(i int) (time.Duration, int) {
+	w := p.word(i)
+	switch {
+	case w == "полчаса":
+		return 30 * time.Minute, 1
+	case w == "half" && p.word(i+1) == "an" && p.word(i+2) == "hour":
+		return 30 * time.Minute, 3
+	}
+
+	// Единица без числа: час, неделю
+	if unit, ok := units[w]; ok && w != "h" && w != "ч" {
+		return unit, 1
+	}
+
+	if m := compactDurationPattern.FindStringSubmatch(strings.ReplaceAll(w, ",", ".")); m != nil && (m[1] != "" || m[2] != "") {
+		return parseCompact(m[1], m[2]), 1
+	}
+
+	amount, ok := numbers[w]
+	if !ok {
+		value, err := strconv.ParseFloat(strings.ReplaceAll(w, ",", "."), 64)
+		if err != nil || value <= 0 {
+			return 0, 0
+		}
+		amount = value
+	}
+
+	unit, ok := units[p.word(i+1)]
+	if !ok {
+		return 0, 0
+	}
+	total := time.Duration(amount * float64(unit))
+
+	// Необязательные минуты после часов: 1 hour 30 minutes, 1 час 30 минут
+	if unit == time.Hour {
+		if minutes, err := strconv.Atoi(p.word(i + 2)); err == nil && units[p.word(i+3)] == time.Minute {
+			return total + time.Duration(minutes)*time.Minute, 4
+		}
+	}
+
+	return total, 2
+}
+
+// parseCompact складывает часы и минуты из компактной записи длительности
+func parseCompact(hours, minutes string) time.Duration {
+	var total time.Duration
+	if hours != "" {
+		h, _ := strconv.ParseFloat(hours, 64)
+		total += time.Duration(h * float64(time.Hour))
+	}
+	if minutes != "" {
+		m, _ := strconv.Atoi(minutes)
+		total += time.Duration(m) * time.Minute
+	}
+	return total
+}
+
+// matchDuration распознает длительность после предлога: for 1h, for an hour, на полчаса, на 2 часа
+func (p *parser) matchDuration(i int) int {
+	if p.duration != 0 || p.end != nil || !durationPrefixes[p.word(i)] {
+		return 0
+	}
+
+	duration, n := p.parseAmount(i + 1)
+	if n == 0 || duration%(24*time.Hour) == 0 {
+		return 0
+	}
+
+	p.duration = duration
+	return n + 1
+}
+
+// matchDate распознает дату: 2025-12-18, 18.12, 18.12.2025, 18 декабря, dec 18, December 18 2025
+func (p *parser) matchDate(i int) int {
+	if p.hasDay || p.relative != nil {
+		return 0
+	}
+
+	j := i
+	if dayPrefixes[p.word(j)] {
+		j++
+	}
+
+	year, month, day, n := p.parseDate(j)
+	if n == 0 {
+		return 0
+	}
+
+	yearGiven := year != 0
+	if !yearGiven {
+		year = p.now.Year()
+	}
+
+	date := time.Date(year, month, day, 0, 0, 0, 0, p.now.Location())
+	if date.Day() != day || date.Month() != month {
+		return 0
+	}
+
+	// Дата без года в прошлом относится к следующему году
+	today := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+	if !yearGiven && date.Before(today) {
+		date = date.AddDate(1, 0, 0)
+	}
+
+	p.hasDay = true
+	p.year, p.month, p.day = date.Date()
+	return j - i + n
+}
+
+// parseDate распознает дату, начинающуюся со слова i; нулевой год означает, что год не указан
+func (p *parser) parseDate(i int) (int, time.Month, int, int) {
+	w := p.word(i)
+
+	if date, err := time.Parse("2006-01-02", w); err == nil {
+		return date.Year(), date.Month(), date.Day(), 1
+	}
+
+	if m := numericDatePattern.FindStringSubmatch(w); m != nil {
+		day, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		year, _ := strconv.Atoi(m[3])
+		if month < 1 || month > 12 {
+			return 0, 0, 0, 0
+		}
+		return year, time.Month(month), day, 1
+	}
+
+	// 18 декабря [2025]
+	if m := dayNumberPattern.FindStringSubmatch(w); m != nil {
+		if month, ok := months[p.word(i+1)]; ok {
+			day, _ := strconv.Atoi(m[1])
+			year, n := p.parseYear(i + 2)
+			return year, month, day, 2 + n
+		}
+	}
+
+	// December 18 [2025]
+	if month, ok := months[w]; ok {
+		if m := dayNumberPattern.FindStringSubmatch(p.word(i + 1)); m != nil {
+			day, _ := strconv.Atoi(m[1])
+			year, n := p.parseYear(i + 2)
+			return year, month, day, 2 + n
+		}
+	}
+
+	return 0, 0, 0, 0
+}
+
+// parseYear распознает необязательный год из четырех цифр
+func (p *parser) parseYear(i int) (int, int) {
+	w := p.word(i)
+	if len(w) != 4 {
+		return 0, 0
+	}
+	year, err := strconv.Atoi(w)
+	if err != nil {
+		return 0, 0
+	}
+	return year, 1
+}
+
+// matchWeekday распознает ближайший день недели: friday, on next monday, в пятницу, в следующий вторник
+func (p *parser) matchWeekday(i int) int {
+	if p.hasDay || p.relative != nil {
+		return 0
+	}
+
+	j := i
+	if dayPrefixes[p.word(j)] {
+		j++
+	}
+	if nextWords[p.word(j)] {
+		j++
+	}
+
+	weekday, ok := weekdays[p.word(j)]
+	if !ok {
+		return 0
+	}
+
+	// Сегодняшний день недели означает ту же дату через неделю
+	offset := (int(weekday) - int(p.now.Weekday()) + 7) % 7
+	if offset == 0 {
+		offset = 7
+	}
+
+	p.setDay(offset)
+	return j - i + 1
+}
+
+// matchClock распознает время начала: at 13:00, at 1pm, в 10, в 7 вечера, 13:30, noon, в полдень
+func (p *parser) matchClock(i int) int {
+	if p.start != nil || p.relative != nil || p.allDay {
+		return 0
+	}
+
+	j := i
+	prefixed := clockPrefixes[p.word(j)]
+	if prefixed {
+		j++
+	}
+
+	c, n := p.parseClock(j)
+	if n == 0 || (!prefixed && !c.explicit) {
+		return 0
+	}
+
+	p.start = c
+	return j - i + n
+}
+
+// matchRange распознает интервал времени: from 10 to 11:30, с 10 до 11, 10:00-11:00
+func (p *parser) matchRange(i int) int {
+	if p.start != nil || p.relative != nil || p.allDay || p.duration != 0 {
+		return 0
+	}
+
+	// Интервал одним словом: 10:00-11:00, а после предлога и 10-11
+	j := i
+	prefixed := rangeStarts[p.word(j)] || clockPrefixes[p.word(j)]
+	if prefixed {
+		j++
+	}
+	if from, to, ok := strings.Cut(p.word(j), "-"); ok {
+		start, startOK := parseClockWord(from)
+		end, endOK := parseClockWord(to)
+		if startOK && endOK && (prefixed || (start.explicit && end.explicit)) {
+			p.setRange(start, end)
+			return j - i + 1
+		}
+	}
+
+	if !rangeStarts[p.word(i)] {
+		return 0
+	}
+
+	start, n := p.parseClock(i + 1)
+	if n == 0 || !rangeEnds[p.word(i+1+n)] {
+		return 0
+	}
+
+	end, m := p.parseClock(i + 2 + n)
+	if m == 0 {
+		return 0
+	}
+
+	p.setRange(start, end)
+	return 2 + n + m
+}
+
+// setRange запоминает интервал; "from 1 to 3pm" означает 13:00-15:00
+func (p *parser) setRange(start, end *clock) {
+	if !start.meridiem && end.meridiem && start.hour < 12 && end.hour >= 12 && start.hour+12 <= end.hour {
+		start.hour += 12
+	}
+	p.start, p.end = start, end
+}
+
+// parseClock распознает время, начинающееся со слова i, с необязательным уточнением времени суток
+func (p *parser) parseClock(i int) (*clock, int) {
+	switch p.word(i) {
+	case "noon", "полдень":
+		return &clock{hour: 12, explicit: true, meridiem: true}, 1
+	case "midnight", "полночь":
+		return &clock{hour: 0, explicit: true, meridiem: true}, 1
+	}
+
+	c, ok := parseClockWord(p.word(i))
+	if !ok {
+		return nil, 0
+	}
+
+	if pm, ok := meridiems[p.word(i+1)]; ok && !c.meridiem && c.hour >= 1 && c.hour <= 12 {
+		applyMeridiem(c, pm)
+		return c, 2
+	}
+
+	return c, 1
+}
+
+// parseClockWord разбирает время, записанное одним словом
+func parseClockWord(w string) (*clock, bool) {
+	m := clockPattern.FindStringSubmatch(w)
+	if m == nil {
+		return nil, false
+	}
+
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	if hour > 23 || minute > 59 {
+		return nil, false
+	}
+
+	c := &clock{hour: hour, minute: minute, explicit: m[2] != "" || m[3] != ""}
+	if m[3] != "" {
+		if hour < 1 || hour > 12 {
+			return nil, false
+		}
+		applyMeridiem(c, m[3] == "pm")
+	}
+
+	return c, true
+}
+
+// applyMeridiem переводит 12-часовое время в 24-часовое
+func applyMeridiem(c *clock, pm bool) {
+	c.explicit, c.meridiem = true, true
+	switch {
+	case pm && c.hour < 12:
+		c.hour += 12
+	case !pm && c.hour == 12:
+		c.hour = 0
+	}
+}
+
+// result собирает интерпретацию фразы
+func (p *parser) result() (*Result, error) {
+	text := strings.Join(p.text, " ")
+	if text == "" {
+		return nil, domain.NewFieldError("text", domain.CodeInvalidPhrase, "не удалось выделить текст события из фразы")
+	}
+	if !p.hasDay && p.start == nil && p.relative == nil {
+		return nil, domain.NewFieldError("text", domain.CodeInvalidPhrase, "не удалось распознать во фразе дату или время события")
+	}
+
+	res := &Result{Text: text, Recognized: p.recognized}
+	loc := p.now.Location()
+
+	if p.relative != nil {
+		res.Start = *p.relative
+		res.End = res.Start.Add(p.durationOr(DefaultDuration))
+		return res, nil
+	}
+
+	dayGiven := p.hasDay
+	if !dayGiven {
+		p.setDay(0)
+	}
+
+	if p.start == nil {
+		res.Start = time.Date(p.year, p.month, p.day, 0, 0, 0, 0, loc)
+		if p.duration != 0 && !p.allDay {
+			res.End = res.Start.Add(p.duration)
+		} else {
+			res.AllDay = true
+		}
+		return res, nil
+	}
+
+	res.Start = time.Date(p.year, p.month, p.day, p.start.hour, p.start.minute, 0, 0, loc)
+
+	// Время без дня, которое сегодня уже прошло, относится к завтрашнему дню
+	if !dayGiven && !res.Start.After(p.now) {
+		p.setDay(1)
+		res.Start = time.Date(p.year, p.month, p.day, p.start.hour, p.start.minute, 0, 0, loc)
+	}
+
+	if p.end != nil {
+		res.End = time.Date(p.year, p.month, p.day, p.end.hour, p.end.minute, 0, 0, loc)
+		// Окончание раньше начала означает переход через полночь
+		if !res.End.After(res.Start) {
+			res.End = time.Date(p.year, p.month, p.day+1, p.end.hour, p.end.minute, 0, 0, loc)
+		}
+		return res, nil
+	}
+
+	res.End = res.Start.Add(p.durationOr(DefaultDuration))
+	return res, nil
+}
+
+// durationOr возвращает указанную длительность или значение по умолчанию
+func (p *parser) durationOr(fallback time.Duration) time.Duration {
+	if p.duration != 0 {
+		return p.duration
+	}
+	return fallback
+}
@@ -0,0 +1,71 @@
+package quickadd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	assert.NoError(t, err)
+
+	// Четверг, 18 декабря 2025 года, 09:30 по Москве
+	now := time.Date(2025, 12, 18, 9, 30, 0, 0, moscow)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 12, day, hour, minute, 0, 0, moscow)
+	}
+
+	tests := []struct {
+		phrase string
+		text   string
+		start  time.Time
+		end    time.Time
+		allDay bool
+	}{
+		{phrase: "lunch with Anna tomorrow at 13:00 for 1h", text: "lunch with Anna", start: at(19, 13, 0), end: at(19, 14, 0)},
+		{phrase: "встреча в пятницу в 10", text: "встреча", start: at(19, 10, 0), end: at(19, 11, 0)},
+		{phrase: "Созвон с командой завтра с 10 до 11:30", text: "Созвон с командой", start: at(19, 10, 0), end: at(19, 11, 30)},
+		{phrase: "call mom at 7pm", text: "call mom", start: at(18, 19, 0), end: at(18, 20, 0)},
+		{phrase: "Ужин в 7 вечера на полтора часа", text: "Ужин", start: at(18, 19, 0), end: at(18, 20, 30)},
+		{phrase: "standup 9:00", text: "standup", start: at(19, 9, 0), end: at(19, 10, 0)},
+		{phrase: "review on next monday from 1 to 3pm", text: "review", start: at(22, 13, 0), end: at(22, 15, 0)},
+		{phrase: "Отпуск 25 декабря весь день", text: "Отпуск", start: at(25, 0, 0), allDay: true},
+		{phrase: "dentist dec 30 at noon for 45 minutes", text: "dentist", start: at(30, 12, 0), end: at(30, 12, 45)},
+		{phrase: "Позвонить через 2 часа", text: "Позвонить", start: at(18, 11, 30), end: at(18, 12, 30)},
+		{phrase: "ночная смена сегодня с 22:00 до 06:00", text: "ночная смена", start: at(18, 22, 0), end: at(19, 6, 0)},
+		{phrase: "Стрижка в четверг", text: "Стрижка", start: at(25, 0, 0), allDay: true},
+		{phrase: "Годовой отчет 2026-01-15 в 10:00 на 1ч30м", text: "Годовой отчет", start: time.Date(2026, 1, 15, 10, 0, 0, 0, moscow), end: time.Date(2026, 1, 15, 11, 30, 0, 0, moscow)},
+		{phrase: "Новогодний корпоратив 10.01 в 19:00", text: "Новогодний корпоратив", start: time.Date(2026, 1, 10, 19, 0, 0, 0, moscow), end: time.Date(2026, 1, 10, 20, 0, 0, 0, moscow)},
+		{phrase: "Обед на работе в 13", text: "Обед на работе", start: at(18, 13, 0), end: at(18, 14, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			result, err := Parse(tt.phrase, now)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.text, result.Text)
+			assert.True(t, tt.start.Equal(result.Start), "начало: %s", result.Start)
+			assert.True(t, tt.end.Equal(result.End), "окончание: %s", result.End)
+			assert.Equal(t, tt.allDay, result.AllDay)
+			assert.NotEmpty(t, result.Recognized)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	now := time.Date(2025, 12, 18, 9, 30, 0, 0, time.UTC)
+
+	for _, phrase := range []string{
+		"",
+		"просто заметка без даты",
+		"завтра в 10",
+	} {
+		t.Run(phrase, func(t *testing.T) {
+			_, err := Parse(phrase, now)
+			assert.Error(t, err)
+		})
+	}
+}
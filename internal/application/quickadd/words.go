@@ -0,0 +1,97 @@
+package quickadd
+
+import "time"
+
+// weekdays - названия дней недели на английском и русском, включая падежные формы
+var weekdays = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday,
+
+	"понедельник": time.Monday, "пн": time.Monday,
+	"вторник": time.Tuesday, "вт": time.Tuesday,
+	"среда": time.Wednesday, "среду": time.Wednesday, "ср": time.Wednesday,
+	"четверг": time.Thursday, "чт": time.Thursday,
+	"пятница": time.Friday, "пятницу": time.Friday, "пт": time.Friday,
+	"суббота": time.Saturday, "субботу": time.Saturday, "сб": time.Saturday,
+	"воскресенье": time.Sunday, "вс": time.Sunday,
+}
+
+// months - названия месяцев на английском и русском (в родительном падеже)
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+
+	"января": time.January, "янв": time.January,
+	"февраля": time.February, "фев": time.February,
+	"марта": time.March, "мар": time.March,
+	"апреля": time.April, "апр": time.April,
+	"мая":  time.May,
+	"июня": time.June, "июн": time.June,
+	"июля": time.July, "июл": time.July,
+	"августа": time.August, "авг": time.August,
+	"сентября": time.September, "сен": time.September,
+	"октября": time.October, "окт": time.October,
+	"ноября": time.November, "ноя": time.November,
+	"декабря": time.December, "дек": time.December,
+}
+
+// numbers - числительные, которые могут стоять вместо цифр
+var numbers = map[string]float64{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"один": 1, "одну": 1, "одна": 1, "два": 2, "две": 2, "три": 3, "четыре": 4, "пять": 5,
+	"полтора": 1.5, "полторы": 1.5,
+}
+
+// units - единицы длительности и относительных сдвигов
+var units = map[string]time.Duration{
+	"minute": time.Minute, "minutes": time.Minute, "min": time.Minute, "mins": time.Minute,
+	"hour": time.Hour, "hours": time.Hour, "hr": time.Hour, "hrs": time.Hour, "h": time.Hour,
+	"day": 24 * time.Hour, "days": 24 * time.Hour,
+	"week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+
+	"минуту": time.Minute, "минуты": time.Minute, "минут": time.Minute, "мин": time.Minute,
+	"час": time.Hour, "часа": time.Hour, "часов": time.Hour, "ч": time.Hour,
+	"день": 24 * time.Hour, "дня": 24 * time.Hour, "дней": 24 * time.Hour,
+	"неделю": 7 * 24 * time.Hour, "недели": 7 * 24 * time.Hour, "недель": 7 * 24 * time.Hour,
+}
+
+// Служебные слова, которые относятся к распознанным фрагментам и не попадают в текст события
+var (
+	// dayPrefixes - предлоги перед днем недели или датой
+	dayPrefixes = map[string]bool{"on": true, "в": true, "во": true, "на": true}
+	// nextWords - уточнения "следующий" перед днем недели
+	nextWords = map[string]bool{
+		"next": true, "this": true, "coming": true,
+		"следующий": true, "следующую": true, "следующее": true, "ближайший": true, "ближайшую": true, "ближайшее": true,
+	}
+	// clockPrefixes - предлоги перед временем
+	clockPrefixes = map[string]bool{"at": true, "@": true, "в": true}
+	// rangeStarts и rangeEnds - слова, обрамляющие интервал времени
+	rangeStarts = map[string]bool{"from": true, "с": true, "со": true}
+	rangeEnds   = map[string]bool{"to": true, "till": true, "until": true, "-": true, "до": true, "по": true}
+	// durationPrefixes - предлоги перед длительностью
+	durationPrefixes = map[string]bool{"for": true, "на": true}
+	// relativePrefixes - предлоги перед относительным сдвигом
+	relativePrefixes = map[string]bool{"in": true, "через": true}
+)
+
+// meridiems - уточнения времени суток после часа: true - после полудня
+var meridiems = map[string]bool{
+	"am": false, "pm": true,
+	"утра": false, "ночи": false, "дня": true, "вечера": true,
+}
@@ -0,0 +1,128 @@
+package application
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"sort"
+	"time"
+)
+
+const (
+	// MaxSlotResults - максимальное количество предлагаемых вариантов времени
+	MaxSlotResults = 50
+	// MinSlotStep - минимальный шаг перебора вариантов времени
+	MinSlotStep = 5 * time.Minute
+)
+
+// FindSlots подбирает время встречи, когда свободны все участники или их кворум.
+// Варианты ранжируются по числу свободных участников, затем по времени начала.
+func (s *EventService) FindSlots(ctx context.Context, query domain.SlotQuery) ([]*domain.Slot, error) {
+	ctx, span := tracer.Start(ctx, "EventService.FindSlots")
+	defer span.End()
+
+	if err := s.validator.ValidateUserIDs(query.Participants); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.ValidateSlotQuery(query); err != nil {
+		return nil, err
+	}
+
+	freeBusy, err := s.GetFreeBusy(ctx, query.Participants, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	notBefore := s.now().Add(query.MinNotice).In(query.From.Location())
+
+	// Варианты, где свободны все участники, ранжируются первыми в порядке времени,
+	// поэтому перебор можно остановить, как только их набралось query.Limit
+	var slots []*domain.Slot
+	var complete int
+	for day := startOfDay(query.From); day.Before(query.To) && complete < query.Limit; day = day.AddDate(0, 0, 1) {
+		if !query.IncludeWeekends && isWeekend(day) {
+			continue
+		}
+
+		windowStart := latest(atClock(day, query.WorkDayStart), query.From, notBefore)
+		windowEnd := earliest(atClock(day, query.WorkDayEnd), query.To)
+
+		// Выравниваем начало по сетке шага относительно начала дня
+		offset := windowStart.Sub(day)
+		if rem := offset % query.Step; rem != 0 {
+			windowStart = windowStart.Add(query.Step - rem)
+		}
+
+		for start := windowStart; !start.Add(query.Duration).After(windowEnd) && complete < query.Limit; start = start.Add(query.Step) {
+			slot := &domain.Slot{
+				Start:       start,
+				End:         start.Add(query.Duration),
+				Available:   []int{},
+				Unavailable: []int{},
+			}
+			for _, item := range freeBusy {
+				if isBusy(item.Busy, slot.Start, slot.End) {
+					slot.Unavailable = append(slot.Unavailable, item.UserID)
+				} else {
+					slot.Available = append(slot.Available, item.UserID)
+				}
+			}
+			if len(slot.Available) >= query.Quorum {
+				slots = append(slots, slot)
+			}
+			if len(slot.Unavailable) == 0 {
+				complete++
+			}
+		}
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool {
+		return len(slots[i].Available) > len(slots[j].Available)
+	})
+
+	if len(slots) > query.Limit {
+		slots = slots[:query.Limit]
+	}
+
+	return slots, nil
+}
+
+// atClock возвращает момент дня day по настенным часам, с учетом перехода на летнее время
+func atClock(day time.Time, offset time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, int(offset/time.Second), 0, day.Location())
+}
+
+// isBusy проверяет, пересекается ли период [start, end) с интервалами занятости
+func isBusy(busy []domain.TimePeriod, start, end time.Time) bool {
+	for _, period := range busy {
+		if period.Start.Before(end) && period.End.After(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWeekend проверяет, является ли день выходным
+func isWeekend(day time.Time) bool {
+	return day.Weekday() == time.Saturday || day.Weekday() == time.Sunday
+}
+
+// latest возвращает самый поздний из моментов времени
+func latest(t time.Time, others ...time.Time) time.Time {
+	for _, o := range others {
+		if o.After(t) {
+			t = o
+		}
+	}
+	return t
+}
+
+// earliest возвращает самый ранний из моментов времени
+func earliest(t time.Time, others ...time.Time) time.Time {
+	for _, o := range others {
+		if o.Before(t) {
+			t = o
+		}
+	}
+	return t
+}
@@ -0,0 +1,175 @@
+package application
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFindSlots(t *testing.T) {
+	// 7 января 2030 года - понедельник
+	at := func(day, hour int) time.Time {
+		return time.Date(2030, 1, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	mockRepo := new(MockEventRepository)
+	service := NewEventService(mockRepo, new(MockTagRepository))
+
+	mockRepo.On("GetOverlapping", 1, mock.Anything, mock.Anything).Return([]*domain.Event{
+		{ID: 1, UserID: 1, Date: at(7, 9), EndDate: at(7, 11)},
+	}, nil)
+	mockRepo.On("GetOverlapping", 2, mock.Anything, mock.Anything).Return([]*domain.Event{
+		{ID: 2, UserID: 2, Date: at(7, 11), EndDate: at(7, 12)},
+	}, nil)
+
+	query := domain.SlotQuery{
+		Participants: []int{1, 2},
+		Duration:     time.Hour,
+		From:         at(5, 0),
+		To:           at(8, 0),
+		WorkDayStart: 9 * time.Hour,
+		WorkDayEnd:   14 * time.Hour,
+		Quorum:       2,
+		Step:         time.Hour,
+		Limit:        10,
+	}
+
+	slots, err := service.FindSlots(context.Background(), query)
+	assert.NoError(t, err)
+
+	// Выходные 5 и 6 января пропускаются, 9-12 заняты
+	var starts []time.Time
+	for _, slot := range slots {
+		starts = append(starts, slot.Start)
+		assert.Equal(t, []int{1, 2}, slot.Available)
+	}
+	assert.Equal(t, []time.Time{at(7, 12), at(7, 13)}, starts)
+
+	// С кворумом в одного участника свободные у всех варианты идут первыми
+	query.Quorum = 1
+	slots, err = service.FindSlots(context.Background(), query)
+	assert.NoError(t, err)
+	assert.Len(t, slots, 5)
+	assert.Equal(t, at(7, 12), slots[0].Start)
+	assert.Equal(t, at(7, 13), slots[1].Start)
+	assert.Equal(t, at(7, 9), slots[2].Start)
+	assert.Equal(t, []int{2}, slots[2].Available)
+	assert.Equal(t, []int{1}, slots[2].Unavailable)
+}
+
+func TestFindSlots_Limit(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2030, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	mockRepo := new(MockEventRepository)
+	service := NewEventService(mockRepo, new(MockTagRepository))
+
+	// Участник 2 занят только в начале первого дня
+	mockRepo.On("GetOverlapping", 1, mock.Anything, mock.Anything).Return([]*domain.Event{}, nil)
+	mockRepo.On("GetOverlapping", 2, mock.Anything, mock.Anything).Return([]*domain.Event{
+		{ID: 1, UserID: 2, Date: at(7, 9, 0), EndDate: at(7, 10, 0)},
+	}, nil)
+
+	query := domain.SlotQuery{
+		Participants: []int{1, 2},
+		Duration:     30 * time.Minute,
+		From:         at(7, 0, 0),
+		To:           at(7, 0, 0).Add(MaxFreeBusySpan),
+		WorkDayStart: 9 * time.Hour,
+		WorkDayEnd:   18 * time.Hour,
+		Quorum:       1,
+		Step:         MinSlotStep,
+		Limit:        3,
+	}
+
+	// Перебор останавливается на первых вариантах, где свободны все; частично свободные идут после них
+	slots, err := service.FindSlots(context.Background(), query)
+	assert.NoError(t, err)
+	if assert.Len(t, slots, 3) {
+		assert.Equal(t, at(7, 10, 0), slots[0].Start)
+		assert.Equal(t, at(7, 10, 5), slots[1].Start)
+		assert.Equal(t, at(7, 10, 10), slots[2].Start)
+		for _, slot := range slots {
+			assert.Equal(t, []int{1, 2}, slot.Available)
+		}
+	}
+}
+
+func TestFindSlots_MinNotice(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2030, 1, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	mockRepo := new(MockEventRepository)
+	service := NewEventService(mockRepo, new(MockTagRepository))
+	service.now = func() time.Time { return at(7, 10).Add(20 * time.Minute) }
+
+	mockRepo.On("GetOverlapping", 1, mock.Anything, mock.Anything).Return([]*domain.Event{}, nil)
+
+	query := domain.SlotQuery{
+		Participants: []int{1},
+		Duration:     time.Hour,
+		From:         at(7, 0),
+		To:           at(8, 0),
+		WorkDayStart: 9 * time.Hour,
+		WorkDayEnd:   18 * time.Hour,
+		MinNotice:    time.Hour,
+		Quorum:       1,
+		Step:         time.Hour,
+		Limit:        10,
+	}
+
+	// Не раньше 11:20, с выравниванием по сетке шага - с 12:00
+	slots, err := service.FindSlots(context.Background(), query)
+	assert.NoError(t, err)
+	if assert.Len(t, slots, 6) {
+		assert.Equal(t, at(7, 12), slots[0].Start)
+		assert.Equal(t, at(7, 17), slots[5].Start)
+	}
+}
+
+func TestFindSlots_Validation(t *testing.T) {
+	base := domain.SlotQuery{
+		Participants: []int{1, 2},
+		Duration:     time.Hour,
+		From:         time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC),
+		To:           time.Date(2030, 1, 8, 0, 0, 0, 0, time.UTC),
+		WorkDayStart: 9 * time.Hour,
+		WorkDayEnd:   18 * time.Hour,
+		Quorum:       2,
+		Step:         15 * time.Minute,
+		Limit:        10,
+	}
+
+	tests := []struct {
+		name   string
+		modify func(q *domain.SlotQuery)
+	}{
+		{name: "Нулевая длительность", modify: func(q *domain.SlotQuery) { q.Duration = 0 }},
+		{name: "Встреча длиннее рабочего дня", modify: func(q *domain.SlotQuery) { q.Duration = 10 * time.Hour }},
+		{name: "Кворум больше числа участников", modify: func(q *domain.SlotQuery) { q.Quorum = 3 }},
+		{name: "Слишком много вариантов", modify: func(q *domain.SlotQuery) { q.Limit = MaxSlotResults + 1 }},
+		{name: "Слишком мелкий шаг", modify: func(q *domain.SlotQuery) { q.Step = time.Minute }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEventRepository)
+			service := NewEventService(mockRepo, new(MockTagRepository))
+
+			query := base
+			tt.modify(&query)
+
+			_, err := service.FindSlots(context.Background(), query)
+			assert.Error(t, err)
+			appErr, ok := err.(*domain.AppError)
+			assert.True(t, ok)
+			assert.Equal(t, domain.StatusBadRequest, appErr.GetStatusCode())
+		})
+	}
+}
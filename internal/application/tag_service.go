@@ -0,0 +1,222 @@
+package application
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"errors"
+	"strings"
+)
+
+const (
+	// MaxTagNameLength - максимальная длина названия тега в символах
+	MaxTagNameLength = 32
+	// MaxEventTags - максимальное количество тегов у одного события
+	MaxEventTags = 10
+)
+
+// TagService реализует бизнес-логику для работы с тегами
+type TagService struct {
+	repo      domain.TagRepository
+	eventRepo domain.EventRepository
+	validator *ServiceValidator
+}
+
+// NewTagService создает новый экземпляр сервиса тегов
+func NewTagService(repo domain.TagRepository, eventRepo domain.EventRepository) *TagService {
+	return &TagService{
+		repo:      repo,
+		eventRepo: eventRepo,
+		validator: NewServiceValidator(),
+	}
+}
+
+// CreateTag создает новый тег пользователя
+func (s *TagService) CreateTag(ctx context.Context, userID int, name, color string) (*domain.Tag, error) {
+	if err := s.validator.ValidateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if err := s.validator.ValidateTagName(name); err != nil {
+		return nil, err
+	}
+
+	if color == "" {
+		color = domain.DefaultTagColor
+	}
+	if err := s.validator.ValidateTagColor(color); err != nil {
+		return nil, err
+	}
+
+	tag := &domain.Tag{
+		UserID: userID,
+		Name:   name,
+		Color:  strings.ToLower(color),
+	}
+
+	// Уникальность названия проверяет репозиторий под своей блокировкой
+	if err := s.repo.Create(tag); err != nil {
+		return nil, tagRepoError(err, "ошибка при создании тега")
+	}
+
+	return tag, nil
+}
+
+// UpdateTag переименовывает тег и/или меняет его цвет; новое название применяется ко всем событиям
+func (s *TagService) UpdateTag(ctx context.Context, id int, userID int, name, color string) (*domain.Tag, error) {
+	if err := s.validator.ValidateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" && color == "" {
+		return nil, domain.NewValidationError("не указано ни название, ни цвет тега")
+	}
+
+	tag, err := s.getOwnTag(id, userID, "нет прав для изменения этого тега")
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *tag
+	if name != "" {
+		if err := s.validator.ValidateTagName(name); err != nil {
+			return nil, err
+		}
+		updated.Name = name
+	}
+	if color != "" {
+		if err := s.validator.ValidateTagColor(color); err != nil {
+			return nil, err
+		}
+		updated.Color = strings.ToLower(color)
+	}
+
+	if updated.Name == tag.Name {
+		if err := s.repo.Update(&updated); err != nil {
+			return nil, tagRepoError(err, "ошибка при обновлении тега")
+		}
+		return &updated, nil
+	}
+
+	// Тег переименовывается в транзакции событий: если обновить тег не удалось,
+	// события откатываются к прежнему названию
+	err = s.eventRepo.WithTx(ctx, func(tx domain.EventRepository) error {
+		if err := tx.ReplaceTag(ctx, userID, tag.Name, updated.Name); err != nil {
+			return domain.NewInternalError("ошибка при переименовании тега в событиях", err)
+		}
+		if err := s.repo.Update(&updated); err != nil {
+			return tagRepoError(err, "ошибка при обновлении тега")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteTag удаляет тег и снимает его со всех событий пользователя
+func (s *TagService) DeleteTag(ctx context.Context, id int, userID int) error {
+	if err := s.validator.ValidateUserID(userID); err != nil {
+		return err
+	}
+
+	tag, err := s.getOwnTag(id, userID, "нет прав для удаления этого тега")
+	if err != nil {
+		return err
+	}
+
+	// Тег снимается с событий в транзакции: если удалить тег не удалось, события не меняются
+	return s.eventRepo.WithTx(ctx, func(tx domain.EventRepository) error {
+		if err := tx.ReplaceTag(ctx, userID, tag.Name, ""); err != nil {
+			return domain.NewInternalError("ошибка при снятии тега с событий", err)
+		}
+		if err := s.repo.Delete(id, userID); err != nil {
+			return tagRepoError(err, "ошибка при удалении тега")
+		}
+		return nil
+	})
+}
+
+// GetTags возвращает теги пользователя
+func (s *TagService) GetTags(ctx context.Context, userID int) ([]*domain.Tag, error) {
+	if err := s.validator.ValidateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	tags, err := s.repo.GetByUser(userID)
+	if err != nil {
+		return nil, domain.NewInternalError("ошибка при получении тегов", err)
+	}
+
+	return tags, nil
+}
+
+// getOwnTag возвращает тег, проверяя, что он принадлежит пользователю
+func (s *TagService) getOwnTag(id int, userID int, deniedMessage string) (*domain.Tag, error) {
+	if err := s.validator.ValidateTagID(id); err != nil {
+		return nil, err
+	}
+
+	tag, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, domain.NewNotFoundError("тег не найден").WithCode(domain.CodeTagNotFound)
+	}
+
+	if tag.UserID != userID {
+		return nil, domain.NewAccessDeniedError(deniedMessage)
+	}
+
+	return tag, nil
+}
+
+// tagRepoError возвращает ошибки репозитория тегов, понятные клиенту, как есть,
+// а остальные оборачивает во внутреннюю ошибку
+func tagRepoError(err error, message string) error {
+	var appErr *domain.AppError
+	if errors.As(err, &appErr) {
+		return err
+	}
+
+	return domain.NewInternalError(message, err)
+}
+
+// resolveTags проверяет, что теги существуют у пользователя, и возвращает их названия
+// в сохраненном написании без повторов
+func resolveTags(repo domain.TagRepository, userID int, names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if len(names) > MaxEventTags {
+		return nil, domain.NewFieldError("tags", domain.CodeInvalidParameter, "слишком много тегов у события")
+	}
+
+	tags, err := repo.GetByUser(userID)
+	if err != nil {
+		return nil, domain.NewInternalError("ошибка при получении тегов", err)
+	}
+
+	known := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		known[strings.ToLower(tag.Name)] = tag.Name
+	}
+
+	resolved := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		canonical, ok := known[key]
+		if !ok {
+			return nil, domain.NewFieldError("tags", domain.CodeTagNotFound, "тег не найден: "+name).
+				WithDetails(domain.MsgTagNotFound, domain.MessageParams{"name": name})
+		}
+		if !seen[key] {
+			seen[key] = true
+			resolved = append(resolved, canonical)
+		}
+	}
+
+	return resolved, nil
+}
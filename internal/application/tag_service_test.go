@@ -0,0 +1,205 @@
+package application
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTagRepository - мок для TagRepository
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) Create(tag *domain.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) Update(tag *domain.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) Delete(id int, userID int) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) GetByID(id int) (*domain.Tag, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) GetByUser(userID int) ([]*domain.Tag, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Tag), args.Error(1)
+}
+
+func TestCreateTag(t *testing.T) {
+	duplicate := domain.NewFieldError("name", domain.CodeTagAlreadyExists, "тег с названием работа уже существует")
+
+	tests := []struct {
+		name          string
+		tagName       string
+		color         string
+		repoErr       error
+		expectedColor string
+		expectedCode  domain.ErrorCode
+	}{
+		{name: "Цвет по умолчанию", tagName: "Здоровье", expectedColor: domain.DefaultTagColor},
+		{name: "Цвет приводится к нижнему регистру", tagName: "Спорт", color: "#00AA00", expectedColor: "#00aa00"},
+		{name: "Название уже занято", tagName: "работа", repoErr: duplicate, expectedCode: domain.CodeTagAlreadyExists},
+		{name: "Ошибка репозитория", tagName: "Спорт", repoErr: errors.New("хранилище недоступно"), expectedCode: domain.CodeInternal},
+		{name: "Некорректный цвет", tagName: "Спорт", color: "green", expectedCode: domain.CodeInvalidParameter},
+		{name: "Пустое название", tagName: "  ", expectedCode: domain.CodeMissingParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTagRepository)
+			service := NewTagService(mockRepo, new(MockEventRepository))
+
+			mockRepo.On("Create", mock.AnythingOfType("*domain.Tag")).Return(tt.repoErr)
+
+			tag, err := service.CreateTag(context.Background(), 1, tt.tagName, tt.color)
+
+			if tt.expectedCode != "" {
+				var appErr *domain.AppError
+				assert.True(t, errors.As(err, &appErr))
+				assert.Equal(t, tt.expectedCode, appErr.ErrorCode())
+				if tt.repoErr == nil {
+					mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.tagName, tag.Name)
+			assert.Equal(t, tt.expectedColor, tag.Color)
+		})
+	}
+}
+
+func TestUpdateTag_RenamePropagatesToEvents(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	mockEventRepo := new(MockEventRepository)
+	service := NewTagService(mockRepo, mockEventRepo)
+
+	tag := &domain.Tag{ID: 1, UserID: 1, Name: "Работа", Color: "#ff0000"}
+	mockRepo.On("GetByID", 1).Return(tag, nil)
+	mockRepo.On("GetByUser", 1).Return([]*domain.Tag{tag}, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*domain.Tag")).Return(nil)
+	mockEventRepo.On("ReplaceTag", 1, "Работа", "Офис").Return(nil)
+
+	updated, err := service.UpdateTag(context.Background(), 1, 1, "Офис", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Офис", updated.Name)
+	assert.Equal(t, "#ff0000", updated.Color)
+	mockEventRepo.AssertExpectations(t)
+
+	// Смена только цвета не затрагивает события
+	_, err = service.UpdateTag(context.Background(), 1, 1, "", "#0000ff")
+	assert.NoError(t, err)
+	mockEventRepo.AssertNumberOfCalls(t, "ReplaceTag", 1)
+}
+
+func TestUpdateTag_RenameFailureReturnsRepositoryError(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	mockEventRepo := new(MockEventRepository)
+	service := NewTagService(mockRepo, mockEventRepo)
+
+	// Название занято параллельным запросом: ошибка репозитория возвращается из транзакции,
+	// и переименование в событиях откатывается
+	mockRepo.On("GetByID", 1).Return(&domain.Tag{ID: 1, UserID: 1, Name: "Работа"}, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*domain.Tag")).Return(domain.NewFieldError("name", domain.CodeTagAlreadyExists, "тег с названием Офис уже существует"))
+	mockEventRepo.On("ReplaceTag", 1, "Работа", "Офис").Return(nil)
+
+	_, err := service.UpdateTag(context.Background(), 1, 1, "Офис", "")
+
+	var appErr *domain.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, domain.CodeTagAlreadyExists, appErr.ErrorCode())
+	mockEventRepo.AssertExpectations(t)
+}
+
+func TestUpdateTag_AccessDenied(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	service := NewTagService(mockRepo, new(MockEventRepository))
+
+	mockRepo.On("GetByID", 1).Return(&domain.Tag{ID: 1, UserID: 2, Name: "Работа"}, nil)
+
+	_, err := service.UpdateTag(context.Background(), 1, 1, "Офис", "")
+
+	assert.Error(t, err)
+	assert.Equal(t, domain.StatusForbidden, err.(*domain.AppError).StatusCode)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestDeleteTag_RemovesTagFromEvents(t *testing.T) {
+	mockRepo := new(MockTagRepository)
+	mockEventRepo := new(MockEventRepository)
+	service := NewTagService(mockRepo, mockEventRepo)
+
+	mockRepo.On("GetByID", 1).Return(&domain.Tag{ID: 1, UserID: 1, Name: "Работа"}, nil)
+	mockRepo.On("Delete", 1, 1).Return(nil)
+	mockEventRepo.On("ReplaceTag", 1, "Работа", "").Return(nil)
+
+	err := service.DeleteTag(context.Background(), 1, 1)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+}
+
+func TestCreateEvent_WithTags(t *testing.T) {
+	tags := []*domain.Tag{
+		{ID: 1, UserID: 1, Name: "Работа"},
+		{ID: 2, UserID: 1, Name: "Здоровье"},
+	}
+	date := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		tags         []string
+		expectedTags []string
+		expectError  bool
+	}{
+		{name: "Теги в сохраненном написании без повторов", tags: []string{"работа", "ЗДОРОВЬЕ", "Работа"}, expectedTags: []string{"Работа", "Здоровье"}},
+		{name: "Неизвестный тег", tags: []string{"Отпуск"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockEventRepository)
+			mockTagRepo := new(MockTagRepository)
+			service := NewEventService(mockRepo, mockTagRepo)
+
+			mockTagRepo.On("GetByUser", 1).Return(tags, nil)
+			mockRepo.On("Create", mock.AnythingOfType("*domain.Event")).Return(nil)
+
+			event, _, err := service.CreateEvent(context.Background(), 1, domain.EventInput{Date: date, Text: "Событие", Tags: tt.tags}, domain.ConflictAllow)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTags, event.Tags)
+		})
+	}
+}
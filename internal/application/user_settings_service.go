@@ -0,0 +1,117 @@
+package application
+
+import (
+	"calendar/internal/domain"
+	"errors"
+	"time"
+)
+
+// UserSettingsService реализует бизнес-логику для работы с настройками пользователей
+type UserSettingsService struct {
+	repo      domain.UserSettingsRepository
+	validator *ServiceValidator
+}
+
+// NewUserSettingsService создает новый экземпляр сервиса настроек
+func NewUserSettingsService(repo domain.UserSettingsRepository) *UserSettingsService {
+	return &UserSettingsService{
+		repo:      repo,
+		validator: NewServiceValidator(),
+	}
+}
+
+// GetSettings возвращает настройки пользователя или настройки по умолчанию
+func (s *UserSettingsService) GetSettings(userID int) (*domain.UserSettings, error) {
+	if err := s.validator.ValidateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.repo.Get(userID)
+	if err != nil {
+		var appErr *domain.AppError
+		if errors.As(err, &appErr) && appErr.GetStatusCode() == domain.StatusNotFound {
+			return defaultUserSettings(userID), nil
+		}
+		return nil, domain.NewInternalError("ошибка при получении настроек пользователя", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings изменяет настройки пользователя; пустые поля patch оставляют значения без изменений
+func (s *UserSettingsService) UpdateSettings(userID int, patch domain.UserSettings) (*domain.UserSettings, error) {
+	settings, err := s.GetSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.TimeZone == "" && patch.WeekStart == "" && patch.Language == "" {
+		return nil, domain.NewValidationError("не указано ни одной настройки для изменения")
+	}
+
+	if patch.TimeZone != "" {
+		if _, err := s.validator.ValidateTimeZone(patch.TimeZone); err != nil {
+			return nil, err
+		}
+		settings.TimeZone = patch.TimeZone
+	}
+
+	if patch.WeekStart != "" {
+		if _, err := s.validator.ValidateWeekStart(patch.WeekStart); err != nil {
+			return nil, err
+		}
+		settings.WeekStart = patch.WeekStart
+	}
+
+	if patch.Language != "" {
+		if err := s.validator.ValidateLanguage(patch.Language); err != nil {
+			return nil, err
+		}
+		settings.Language = patch.Language
+	}
+
+	if err := s.repo.Save(settings); err != nil {
+		return nil, domain.NewInternalError("ошибка при сохранении настроек пользователя", err)
+	}
+
+	return settings, nil
+}
+
+// Location возвращает часовой пояс пользователя
+func (s *UserSettingsService) Location(userID int) (*time.Location, error) {
+	settings, err := s.GetSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.validator.ValidateTimeZone(settings.TimeZone)
+}
+
+// WeekStart возвращает первый день недели пользователя
+func (s *UserSettingsService) WeekStart(userID int) (time.Weekday, error) {
+	settings, err := s.GetSettings(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.validator.ValidateWeekStart(settings.WeekStart)
+}
+
+// Language возвращает язык сообщений API, выбранный пользователем; пустую строку, если он не выбран
+func (s *UserSettingsService) Language(userID int) (string, error) {
+	settings, err := s.GetSettings(userID)
+	if err != nil {
+		return "", err
+	}
+
+	return settings.Language, nil
+}
+
+// defaultUserSettings возвращает настройки пользователя по умолчанию
+func defaultUserSettings(userID int) *domain.UserSettings {
+	return &domain.UserSettings{
+		UserID:    userID,
+		TimeZone:  domain.DefaultTimeZone,
+		WeekStart: domain.DefaultWeekStart,
+	}
+}
@@ -0,0 +1,98 @@
+package application
+
+import (
+	"calendar/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUserSettingsRepository - мок для UserSettingsRepository
+type MockUserSettingsRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserSettingsRepository) Get(userID int) (*domain.UserSettings, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserSettings), args.Error(1)
+}
+
+func (m *MockUserSettingsRepository) Save(settings *domain.UserSettings) error {
+	args := m.Called(settings)
+	return args.Error(0)
+}
+
+func TestUserSettingsService_Location(t *testing.T) {
+	mockRepo := new(MockUserSettingsRepository)
+	service := NewUserSettingsService(mockRepo)
+
+	mockRepo.On("Get", 1).Return(&domain.UserSettings{UserID: 1, TimeZone: "Europe/Moscow"}, nil)
+	mockRepo.On("Get", 2).Return(nil, domain.NewNotFoundError("настройки пользователя не найдены"))
+
+	loc, err := service.Location(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Moscow", loc.String())
+
+	// Пользователь без настроек получает часовой пояс по умолчанию
+	loc, err = service.Location(2)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DefaultTimeZone, loc.String())
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserSettingsService_UpdateSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		patch       domain.UserSettings
+		expectError bool
+	}{
+		{name: "Успешное обновление часового пояса", patch: domain.UserSettings{TimeZone: "Asia/Yekaterinburg"}},
+		{name: "Успешное обновление первого дня недели", patch: domain.UserSettings{WeekStart: "sunday"}},
+		{name: "Неизвестный часовой пояс", patch: domain.UserSettings{TimeZone: "Mars/Olympus"}, expectError: true},
+		{name: "Некорректный первый день недели", patch: domain.UserSettings{WeekStart: "friday"}, expectError: true},
+		{name: "Успешное обновление языка", patch: domain.UserSettings{Language: "en"}},
+		{name: "Неподдерживаемый язык", patch: domain.UserSettings{Language: "de"}, expectError: true},
+		{name: "Пустые изменения", patch: domain.UserSettings{}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUserSettingsRepository)
+			service := NewUserSettingsService(mockRepo)
+
+			mockRepo.On("Get", 1).Return(nil, domain.NewNotFoundError("настройки пользователя не найдены"))
+			if !tt.expectError {
+				mockRepo.On("Save", mock.AnythingOfType("*domain.UserSettings")).Return(nil)
+			}
+
+			settings, err := service.UpdateSettings(1, tt.patch)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				appErr, ok := err.(*domain.AppError)
+				assert.True(t, ok)
+				assert.Equal(t, domain.StatusBadRequest, appErr.GetStatusCode())
+			} else {
+				assert.NoError(t, err)
+				if tt.patch.TimeZone != "" {
+					assert.Equal(t, tt.patch.TimeZone, settings.TimeZone)
+				} else {
+					assert.Equal(t, domain.DefaultTimeZone, settings.TimeZone)
+				}
+				if tt.patch.WeekStart != "" {
+					assert.Equal(t, tt.patch.WeekStart, settings.WeekStart)
+				} else {
+					assert.Equal(t, domain.DefaultWeekStart, settings.WeekStart)
+				}
+				assert.Equal(t, tt.patch.Language, settings.Language)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
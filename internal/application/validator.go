@@ -2,6 +2,21 @@ package application
 
 import (
 	"calendar/internal/domain"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	// tagColorPattern - формат цвета тега
+	tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+	// propertyKeyPattern - формат ключа пользовательского свойства события
+	propertyKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
 )
 
 // ServiceValidator содержит методы для валидации в сервисном слое
@@ -15,7 +30,7 @@ func NewServiceValidator() *ServiceValidator {
 // ValidateUserID проверяет корректность ID пользователя
 func (v *ServiceValidator) ValidateUserID(userID int) error {
 	if userID <= 0 {
-		return domain.NewValidationError("некорректный ID пользователя")
+		return domain.NewFieldError("user_id", domain.CodeInvalidParameter, "некорректный ID пользователя")
 	}
 	return nil
 }
@@ -23,7 +38,7 @@ func (v *ServiceValidator) ValidateUserID(userID int) error {
 // ValidateEventText проверяет корректность текста события
 func (v *ServiceValidator) ValidateEventText(text string) error {
 	if text == "" {
-		return domain.NewValidationError("текст события не может быть пустым")
+		return domain.NewFieldError("text", domain.CodeMissingParameter, "текст события не может быть пустым")
 	}
 	return nil
 }
@@ -31,7 +46,15 @@ func (v *ServiceValidator) ValidateEventText(text string) error {
 // ValidateEventID проверяет корректность ID события
 func (v *ServiceValidator) ValidateEventID(id int) error {
 	if id <= 0 {
-		return domain.NewValidationError("некорректный ID события")
+		return domain.NewFieldError("id", domain.CodeInvalidParameter, "некорректный ID события")
+	}
+	return nil
+}
+
+// ValidateTagID проверяет корректность ID тега
+func (v *ServiceValidator) ValidateTagID(id int) error {
+	if id <= 0 {
+		return domain.NewFieldError("id", domain.CodeInvalidParameter, "некорректный ID тега")
 	}
 	return nil
 }
@@ -46,3 +69,259 @@ func (v *ServiceValidator) ValidateEventData(userID int, text string) error {
 	}
 	return nil
 }
+
+// ValidateEventPeriod проверяет, что дата окончания события не раньше даты начала
+func (v *ServiceValidator) ValidateEventPeriod(date, endDate time.Time) error {
+	if !endDate.IsZero() && endDate.Before(date) {
+		return domain.NewFieldError("end_date", domain.CodeInvalidDate, "дата окончания события раньше даты начала").
+			WithDetails(domain.MsgEndBeforeStart, domain.MessageParams{"start": "date"})
+	}
+	return nil
+}
+
+// ValidateEventDetails проверяет заголовок, описание, место, ссылку и пользовательские свойства события
+func (v *ServiceValidator) ValidateEventDetails(input domain.EventInput) error {
+	if err := v.validateLength("title", input.Title, MaxEventTitleLength); err != nil {
+		return err
+	}
+	if err := v.validateLength("description", input.Description, MaxEventDescriptionLength); err != nil {
+		return err
+	}
+	if err := v.ValidateEventLocation(input.Location); err != nil {
+		return err
+	}
+	if err := v.ValidateEventURL(input.URL); err != nil {
+		return err
+	}
+	return v.ValidateEventProperties(input.Properties)
+}
+
+// ValidateEventLocation проверяет место проведения события
+func (v *ServiceValidator) ValidateEventLocation(location *domain.Location) error {
+	if location == nil {
+		return nil
+	}
+	if location.Text == "" && location.Geo == nil {
+		return domain.NewFieldError("location", domain.CodeInvalidParameter, "место проведения события не может быть пустым")
+	}
+	if err := v.validateLength("location", location.Text, MaxEventLocationLength); err != nil {
+		return err
+	}
+	if geo := location.Geo; geo != nil {
+		// Сравнения с NaN ложны, поэтому NaN проверяется отдельно
+		if math.IsNaN(geo.Lat) || geo.Lat < -90 || geo.Lat > 90 {
+			return domain.NewFieldError("lat", domain.CodeInvalidParameter, "широта должна быть в диапазоне от -90 до 90")
+		}
+		if math.IsNaN(geo.Lon) || geo.Lon < -180 || geo.Lon > 180 {
+			return domain.NewFieldError("lon", domain.CodeInvalidParameter, "долгота должна быть в диапазоне от -180 до 180")
+		}
+	}
+	return nil
+}
+
+// ValidateEventURL проверяет ссылку на конференцию: пустая или абсолютная http(s)-ссылка
+func (v *ServiceValidator) ValidateEventURL(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > MaxEventURLLength {
+		return domain.NewFieldError("url", domain.CodeInvalidParameter, fmt.Sprintf("ссылка слишком длинная, максимум %d символов", MaxEventURLLength)).
+			WithDetails(domain.MsgTooLong, domain.MessageParams{"max": strconv.Itoa(MaxEventURLLength)})
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domain.NewFieldError("url", domain.CodeInvalidParameter, "некорректная ссылка, используйте абсолютный адрес http или https")
+	}
+	return nil
+}
+
+// ValidateEventProperties проверяет пользовательские свойства события
+func (v *ServiceValidator) ValidateEventProperties(properties map[string]string) error {
+	if len(properties) > MaxEventProperties {
+		return domain.NewValidationError(fmt.Sprintf("слишком много пользовательских свойств, максимум %d", MaxEventProperties))
+	}
+	for key, value := range properties {
+		if len(key) > MaxPropertyKeyLength || !propertyKeyPattern.MatchString(key) {
+			return domain.NewFieldError("prop."+key, domain.CodeInvalidParameter, "некорректный ключ свойства "+key+": допустимы латинские буквы, цифры, '_', '.' и '-'")
+		}
+		if err := v.validateLength("prop."+key, value, MaxPropertyValueLength); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateLength проверяет, что длина поля name в символах не превышает max
+func (v *ServiceValidator) validateLength(name, value string, max int) error {
+	if utf8.RuneCountInString(value) > max {
+		return domain.NewFieldError(name, domain.CodeInvalidParameter, fmt.Sprintf("длина поля %s превышает %d символов", name, max)).
+			WithDetails(domain.MsgTooLong, domain.MessageParams{"max": strconv.Itoa(max)})
+	}
+	return nil
+}
+
+// ValidateUserIDs проверяет список ID пользователей
+func (v *ServiceValidator) ValidateUserIDs(userIDs []int) error {
+	if len(userIDs) == 0 {
+		return domain.NewValidationError("список пользователей не может быть пустым")
+	}
+	if len(userIDs) > MaxFreeBusyUsers {
+		return domain.NewValidationError(fmt.Sprintf("слишком много пользователей, максимум %d", MaxFreeBusyUsers))
+	}
+	for _, userID := range userIDs {
+		if err := v.ValidateUserID(userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateTimeRange проверяет корректность периода и его максимальную длину
+func (v *ServiceValidator) ValidateTimeRange(from, to time.Time, maxSpan time.Duration) error {
+	if !from.Before(to) {
+		return domain.NewFieldError("from", domain.CodeInvalidDate, "начало периода должно быть раньше его окончания").WithField("to").
+			WithDetails(domain.MsgPeriodStartAfterEnd, domain.MessageParams{"start": "from", "end": "to"})
+	}
+	if to.Sub(from) > maxSpan {
+		return domain.NewFieldError("to", domain.CodeInvalidDate, "слишком длинный период запроса")
+	}
+	return nil
+}
+
+// ValidateSlotQuery проверяет параметры поиска времени для встречи
+func (v *ServiceValidator) ValidateSlotQuery(query domain.SlotQuery) error {
+	if query.Duration <= 0 || query.Duration > 24*time.Hour {
+		return domain.NewFieldError("duration", domain.CodeInvalidParameter, "длительность встречи должна быть от 1 минуты до 24 часов")
+	}
+	if query.WorkDayStart < 0 || query.WorkDayEnd > 24*time.Hour || query.WorkDayStart >= query.WorkDayEnd {
+		return domain.NewFieldError("work_start", domain.CodeInvalidParameter, "некорректные рабочие часы").WithField("work_end")
+	}
+	if query.WorkDayEnd-query.WorkDayStart < query.Duration {
+		return domain.NewFieldError("duration", domain.CodeInvalidParameter, "встреча не помещается в рабочие часы")
+	}
+	if query.MinNotice < 0 {
+		return domain.NewFieldError("min_notice", domain.CodeInvalidParameter, "минимальное время до встречи не может быть отрицательным")
+	}
+	if query.Quorum < 1 || query.Quorum > len(query.Participants) {
+		return domain.NewFieldError("quorum", domain.CodeInvalidParameter, "кворум должен быть от 1 до числа участников")
+	}
+	if query.Step < MinSlotStep {
+		return domain.NewFieldError("step", domain.CodeInvalidParameter, "шаг поиска должен быть не меньше 5 минут")
+	}
+	return v.ValidateLimit(query.Limit, MaxSlotResults)
+}
+
+// ValidateConflictPolicy проверяет политику обработки пересечений
+func (v *ServiceValidator) ValidateConflictPolicy(policy domain.ConflictPolicy) error {
+	switch policy {
+	case domain.ConflictAllow, domain.ConflictWarn, domain.ConflictReject:
+		return nil
+	}
+	return domain.NewFieldError("conflict_policy", domain.CodeInvalidParameter, "некорректная политика пересечений, используйте allow, warn или reject")
+}
+
+// ValidateTimeZone проверяет имя часового пояса IANA и возвращает его
+func (v *ServiceValidator) ValidateTimeZone(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, domain.NewFieldError("time_zone", domain.CodeInvalidParameter, "часовой пояс не может быть пустым")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, domain.NewFieldError("time_zone", domain.CodeInvalidParameter, "неизвестный часовой пояс: "+name).
+			WithDetails(domain.MsgUnknownTimeZone, domain.MessageParams{"value": name})
+	}
+	return loc, nil
+}
+
+// ValidateWeekStart проверяет первый день недели и возвращает его
+func (v *ServiceValidator) ValidateWeekStart(name string) (time.Weekday, error) {
+	weekday, ok := domain.ParseWeekStart(name)
+	if !ok {
+		return 0, domain.NewFieldError("week_start", domain.CodeInvalidParameter, "некорректный первый день недели, используйте monday, sunday или saturday")
+	}
+	return weekday, nil
+}
+
+// ValidateLanguage проверяет язык сообщений API
+func (v *ServiceValidator) ValidateLanguage(lang string) error {
+	if !domain.IsSupportedLanguage(lang) {
+		return domain.NewFieldError("language", domain.CodeInvalidParameter, "неподдерживаемый язык, используйте ru или en")
+	}
+	return nil
+}
+
+// ValidateLimit проверяет количество запрашиваемых элементов
+func (v *ServiceValidator) ValidateLimit(limit, max int) error {
+	if limit < 1 || limit > max {
+		return domain.NewFieldError("limit", domain.CodeInvalidParameter, fmt.Sprintf("limit должен быть от 1 до %d", max)).
+			WithDetails(domain.MsgLimitOutOfRange, domain.MessageParams{"max": strconv.Itoa(max)})
+	}
+	return nil
+}
+
+// ValidatePageRequest проверяет параметры страницы; нулевой limit означает выборку без ограничения
+func (v *ServiceValidator) ValidatePageRequest(page domain.PageRequest) error {
+	switch page.Sort {
+	case "", domain.SortByDate, domain.SortByUpdatedAt:
+	default:
+		return domain.NewFieldError("sort", domain.CodeInvalidParameter, "некорректный порядок сортировки, используйте date или updated_at")
+	}
+	if page.Limit < 0 || page.Limit > MaxPageLimit {
+		return domain.NewFieldError("limit", domain.CodeInvalidParameter, fmt.Sprintf("limit должен быть от 1 до %d", MaxPageLimit)).
+			WithDetails(domain.MsgLimitOutOfRange, domain.MessageParams{"max": strconv.Itoa(MaxPageLimit)})
+	}
+	return nil
+}
+
+// ValidateSearchQuery проверяет поисковый запрос
+func (v *ServiceValidator) ValidateSearchQuery(query string) error {
+	if strings.TrimSpace(query) == "" {
+		return domain.NewFieldError("q", domain.CodeMissingParameter, "поисковый запрос не может быть пустым")
+	}
+	if utf8.RuneCountInString(query) > MaxSearchQueryLength {
+		return domain.NewFieldError("q", domain.CodeInvalidParameter, fmt.Sprintf("поисковый запрос длиннее %d символов", MaxSearchQueryLength)).
+			WithDetails(domain.MsgTooLong, domain.MessageParams{"max": strconv.Itoa(MaxSearchQueryLength)})
+	}
+	return nil
+}
+
+// ValidateTagName проверяет название тега
+func (v *ServiceValidator) ValidateTagName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return domain.NewFieldError("name", domain.CodeMissingParameter, "название тега не может быть пустым")
+	}
+	if utf8.RuneCountInString(name) > MaxTagNameLength {
+		return domain.NewFieldError("name", domain.CodeInvalidParameter, fmt.Sprintf("название тега длиннее %d символов", MaxTagNameLength)).
+			WithDetails(domain.MsgTooLong, domain.MessageParams{"max": strconv.Itoa(MaxTagNameLength)})
+	}
+	if strings.Contains(name, ",") {
+		return domain.NewFieldError("name", domain.CodeInvalidParameter, "название тега не может содержать запятую")
+	}
+	return nil
+}
+
+// ValidateTagColor проверяет цвет тега в формате #RRGGBB
+func (v *ServiceValidator) ValidateTagColor(color string) error {
+	if !tagColorPattern.MatchString(color) {
+		return domain.NewFieldError("color", domain.CodeInvalidParameter, "некорректный цвет тега, используйте формат #RRGGBB")
+	}
+	return nil
+}
+
+// ValidateBatch проверяет размер пакета и виды операций
+func (v *ServiceValidator) ValidateBatch(operations []domain.BatchOperation) error {
+	if len(operations) == 0 {
+		return domain.NewValidationError("пакет не содержит операций")
+	}
+	if len(operations) > MaxBatchOperations {
+		return domain.NewValidationError(fmt.Sprintf("слишком много операций в пакете, максимум %d", MaxBatchOperations))
+	}
+	for i, op := range operations {
+		switch op.Type {
+		case domain.ChangeCreate, domain.ChangeUpdate, domain.ChangeDelete:
+		default:
+			return domain.NewValidationError(fmt.Sprintf("операция %d: неизвестный тип %q, используйте create, update или delete", i, op.Type))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package domain
+
+// ChangeType - вид операции пакета
+type ChangeType string
+
+const (
+	// ChangeCreate - создание события
+	ChangeCreate ChangeType = "create"
+	// ChangeUpdate - изменение события
+	ChangeUpdate ChangeType = "update"
+	// ChangeDelete - удаление события
+	ChangeDelete ChangeType = "delete"
+)
+
+// BatchOperation - операция пакетного запроса
+type BatchOperation struct {
+	Type  ChangeType
+	ID    int
+	Input EventInput
+}
+
+// BatchItemResult - результат одной операции пакета
+type BatchItemResult struct {
+	Index     int          `json:"index"`
+	Op        ChangeType   `json:"op"`
+	ID        int          `json:"id,omitempty"`
+	Status    int          `json:"status"`
+	Event     *Event       `json:"event,omitempty"`
+	Conflicts []*Event     `json:"conflicts,omitempty"`
+	Error     string       `json:"error,omitempty"`
+	Code      ErrorCode    `json:"code,omitempty"`
+	Details   []FieldError `json:"details,omitempty"` // ошибки в отдельных полях операции
+}
+
+// BatchResult - результат пакетного запроса; Applied означает, что выполнены все операции
+type BatchResult struct {
+	Applied bool               `json:"applied"`
+	Items   []*BatchItemResult `json:"items"`
+}
@@ -4,11 +4,92 @@ import (
 	"net/http"
 )
 
+// ErrorCode - стабильный машиночитаемый код ошибки. В отличие от текста сообщения
+// коды не меняются, и клиенты могут на них опираться.
+type ErrorCode string
+
+// Коды ошибок
+const (
+	CodeValidation            ErrorCode = "VALIDATION_ERROR"
+	CodeMissingParameter      ErrorCode = "MISSING_PARAMETER"
+	CodeInvalidParameter      ErrorCode = "INVALID_PARAMETER"
+	CodeInvalidDate           ErrorCode = "INVALID_DATE"
+	CodeInvalidJSON           ErrorCode = "INVALID_JSON"
+	CodeInvalidFilter         ErrorCode = "INVALID_FILTER"
+	CodeInvalidCursor         ErrorCode = "INVALID_CURSOR"
+	CodeInvalidPhrase         ErrorCode = "INVALID_PHRASE"
+	CodeInvalidIdempotencyKey ErrorCode = "INVALID_IDEMPOTENCY_KEY"
+	CodeRequestTooLarge       ErrorCode = "REQUEST_TOO_LARGE"
+	CodeUnauthorized          ErrorCode = "UNAUTHORIZED"
+	CodeAccessDenied          ErrorCode = "ACCESS_DENIED"
+	CodeNotFound              ErrorCode = "NOT_FOUND"
+	CodeEventNotFound         ErrorCode = "EVENT_NOT_FOUND"
+	CodeTagNotFound           ErrorCode = "TAG_NOT_FOUND"
+	CodeSettingsNotFound      ErrorCode = "SETTINGS_NOT_FOUND"
+	CodeConflict              ErrorCode = "CONFLICT"
+	CodeEventConflict         ErrorCode = "EVENT_CONFLICT"
+	CodeTagAlreadyExists      ErrorCode = "TAG_ALREADY_EXISTS"
+	CodeIdempotencyKeyReused  ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	CodeIdempotencyInProgress ErrorCode = "IDEMPOTENCY_REQUEST_IN_PROGRESS"
+	CodeBusinessLogic         ErrorCode = "BUSINESS_LOGIC_ERROR"
+	CodeRequestCanceled       ErrorCode = "REQUEST_CANCELED"
+	CodeInternal              ErrorCode = "INTERNAL_ERROR"
+	CodeUnprocessable         ErrorCode = "UNPROCESSABLE"
+	CodeFailedDependency      ErrorCode = "FAILED_DEPENDENCY"
+	CodeServiceUnavailable    ErrorCode = "SERVICE_UNAVAILABLE"
+	CodeUnknown               ErrorCode = "UNKNOWN_ERROR"
+)
+
+// MessageKey - ключ шаблона подробного сообщения об ошибке в каталоге переводов. Шаблон по коду
+// ошибки называет только поле, а подробный сохраняет при переводе позицию, значение, связанное поле.
+type MessageKey string
+
+// Ключи подробных сообщений; в комментариях - параметры шаблона помимо имени поля
+const (
+	MsgFilterEmpty             MessageKey = "filter.empty"
+	MsgFilterTooLong           MessageKey = "filter.too_long"             // max
+	MsgFilterUnexpectedToken   MessageKey = "filter.unexpected_token"     // position, token
+	MsgFilterUnexpectedEnd     MessageKey = "filter.unexpected_end"       // position
+	MsgFilterExpectedParen     MessageKey = "filter.expected_paren"       // position, token
+	MsgFilterExpectedCondition MessageKey = "filter.expected_condition"   // position, token
+	MsgFilterExpectedOperator  MessageKey = "filter.expected_operator"    // position, name, token
+	MsgFilterExpectedValue     MessageKey = "filter.expected_value"       // position, operator, token
+	MsgFilterExpectedNotEqual  MessageKey = "filter.expected_not_equal"   // position
+	MsgFilterUnclosedQuote     MessageKey = "filter.unclosed_quote"       // position
+	MsgFilterUnknownField      MessageKey = "filter.unknown_field"        // position, token, fields
+	MsgFilterUnsupportedOp     MessageKey = "filter.unsupported_operator" // position, operator
+	MsgFilterExpectedInteger   MessageKey = "filter.expected_integer"     // position, token
+	MsgFilterInvalidDate       MessageKey = "filter.invalid_date"         // position, token
+	MsgEndBeforeStart          MessageKey = "period.end_before_start"     // start
+	MsgPeriodStartAfterEnd     MessageKey = "period.start_after_end"      // start, end
+	MsgTooLong                 MessageKey = "value.too_long"              // max
+	MsgLimitOutOfRange         MessageKey = "value.limit_out_of_range"    // max
+	MsgUnknownTimeZone         MessageKey = "value.unknown_time_zone"     // value
+	MsgUnknownBatchOperation   MessageKey = "batch.unknown_operation"     // value
+	MsgTagNotFound             MessageKey = "tag.not_found"               // name
+	MsgTagAlreadyExists        MessageKey = "tag.already_exists"          // name
+)
+
+// MessageParams - значения параметров шаблона подробного сообщения
+type MessageParams map[string]string
+
+// FieldError описывает ошибку в конкретном параметре или поле запроса
+type FieldError struct {
+	Field   string    `json:"field"`
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+	// Key и Params нужны для перевода сообщения и в ответ не выводятся
+	Key    MessageKey    `json:"-"`
+	Params MessageParams `json:"-"`
+}
+
 // AppError представляет ошибку приложения с HTTP статус-кодом
 type AppError struct {
 	Message    string
 	StatusCode int
 	Err        error
+	Code       ErrorCode    // код ошибки; если не задан, определяется по статус-коду
+	Fields     []FieldError // ошибки в отдельных полях запроса
 }
 
 // Error возвращает сообщение об ошибке
@@ -29,6 +110,62 @@ func (e *AppError) GetStatusCode() int {
 	return e.StatusCode
 }
 
+// ErrorCode возвращает код ошибки; для ошибок без кода - код по умолчанию для ее статус-кода
+func (e *AppError) ErrorCode() ErrorCode {
+	if e.Code != "" {
+		return e.Code
+	}
+	return defaultErrorCode(e.StatusCode)
+}
+
+// WithCode задает код ошибки и возвращает ту же ошибку
+func (e *AppError) WithCode(code ErrorCode) *AppError {
+	e.Code = code
+	return e
+}
+
+// WithField отмечает поле запроса, к которому относится ошибка, и возвращает ту же ошибку.
+// Код и сообщение поля совпадают с кодом и сообщением ошибки.
+func (e *AppError) WithField(field string) *AppError {
+	e.Fields = append(e.Fields, FieldError{Field: field, Code: e.ErrorCode(), Message: e.Message})
+	return e
+}
+
+// WithDetails задает всем полям ошибки шаблон подробного сообщения key с параметрами params
+// и возвращает ту же ошибку
+func (e *AppError) WithDetails(key MessageKey, params MessageParams) *AppError {
+	for i := range e.Fields {
+		e.Fields[i].Key = key
+		e.Fields[i].Params = params
+	}
+	return e
+}
+
+// defaultErrorCode возвращает код ошибки по умолчанию для HTTP статус-кода
+func defaultErrorCode(statusCode int) ErrorCode {
+	switch statusCode {
+	case StatusBadRequest:
+		return CodeValidation
+	case StatusUnauthorized:
+		return CodeUnauthorized
+	case StatusForbidden:
+		return CodeAccessDenied
+	case StatusNotFound:
+		return CodeNotFound
+	case StatusConflict:
+		return CodeConflict
+	case StatusUnprocessableEntity:
+		return CodeUnprocessable
+	case StatusFailedDependency:
+		return CodeFailedDependency
+	case StatusInternalServerError:
+		return CodeInternal
+	case StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	}
+	return CodeUnknown
+}
+
 // NewAppError создает новую ошибку приложения
 func NewAppError(message string, statusCode int, err error) *AppError {
 	return &AppError{
@@ -38,12 +175,16 @@ func NewAppError(message string, statusCode int, err error) *AppError {
 	}
 }
 
-// HTTP статус-коды для ошибок
+// HTTP статус-коды для ошибок и результатов операций
 const (
+	StatusOK                  = http.StatusOK                  // 200
 	StatusBadRequest          = http.StatusBadRequest          // 400
 	StatusUnauthorized        = http.StatusUnauthorized        // 401
 	StatusForbidden           = http.StatusForbidden           // 403
 	StatusNotFound            = http.StatusNotFound            // 404
+	StatusConflict            = http.StatusConflict            // 409
+	StatusUnprocessableEntity = http.StatusUnprocessableEntity // 422
+	StatusFailedDependency    = http.StatusFailedDependency    // 424
 	StatusInternalServerError = http.StatusInternalServerError // 500
 	StatusServiceUnavailable  = http.StatusServiceUnavailable  // 503
 )
@@ -53,8 +194,15 @@ func NewValidationError(message string) *AppError {
 	return NewAppError(message, StatusBadRequest, nil)
 }
 
+// NewFieldError создает ошибку валидации поля field с кодом code
+func NewFieldError(field string, code ErrorCode, message string) *AppError {
+	return NewValidationError(message).WithCode(code).WithField(field)
+}
+
+// NewBusinessLogicError создает ошибку нарушения бизнес-правила: запрос корректен,
+// но операция не может быть выполнена
 func NewBusinessLogicError(message string) *AppError {
-	return NewAppError(message, StatusServiceUnavailable, nil)
+	return NewAppError(message, StatusUnprocessableEntity, nil).WithCode(CodeBusinessLogic)
 }
 
 func NewNotFoundError(message string) *AppError {
@@ -68,3 +216,23 @@ func NewAccessDeniedError(message string) *AppError {
 func NewInternalError(message string, err error) *AppError {
 	return NewAppError(message, StatusInternalServerError, err)
 }
+
+// ConflictError - ошибка пересечения с существующими событиями вместе со списком этих событий.
+// Остается ошибкой приложения: errors.As находит в ней и *ConflictError, и *AppError.
+type ConflictError struct {
+	*AppError
+	Conflicts []*Event
+}
+
+// Unwrap возвращает ошибку приложения
+func (e *ConflictError) Unwrap() error {
+	return e.AppError
+}
+
+// NewConflictError создает ошибку пересечения с существующими событиями
+func NewConflictError(message string, conflicts []*Event) *ConflictError {
+	return &ConflictError{
+		AppError:  NewAppError(message, StatusConflict, nil).WithCode(CodeEventConflict),
+		Conflicts: conflicts,
+	}
+}
@@ -1,35 +1,158 @@
 package domain
 
 import (
+	"context"
+	"strings"
 	"time"
 )
 
 // Event представляет событие в календаре
 type Event struct {
-	ID        int       `json:"id"`
-	UserID    int       `json:"user_id"`
-	Date      time.Time `json:"date"`
-	Text      string    `json:"text"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          int               `json:"id"`
+	UserID      int               `json:"user_id"`
+	Date        time.Time         `json:"date"`
+	EndDate     time.Time         `json:"end_date,omitzero"`
+	Text        string            `json:"text"`
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Location    *Location         `json:"location,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Properties  map[string]string `json:"properties,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
 }
 
-// EventRepository определяет интерфейс для работы с событиями
+// Location описывает место проведения события: произвольный текст и необязательные координаты
+type Location struct {
+	Text string    `json:"text,omitempty"`
+	Geo  *GeoPoint `json:"geo,omitempty"`
+}
+
+// GeoPoint - географические координаты в градусах
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Summary возвращает краткое название события: заголовок, а при его отсутствии - текст
+func (e *Event) Summary() string {
+	if e.Title != "" {
+		return e.Title
+	}
+	return e.Text
+}
+
+// LocationText возвращает текстовое описание места проведения или пустую строку
+func (e *Event) LocationText() string {
+	if e.Location == nil {
+		return ""
+	}
+	return e.Location.Text
+}
+
+// HasTag проверяет, отмечено ли событие тегом (без учета регистра)
+func (e *Event) HasTag(name string) bool {
+	for _, tag := range e.Tags {
+		if strings.EqualFold(tag, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultEventDuration - длительность события без явной даты окончания
+const DefaultEventDuration = 24 * time.Hour
+
+// End возвращает момент окончания события
+func (e *Event) End() time.Time {
+	if e.EndDate.IsZero() {
+		return e.Date.Add(DefaultEventDuration)
+	}
+	return e.EndDate
+}
+
+// Overlaps проверяет, пересекается ли событие с периодом [start, end)
+func (e *Event) Overlaps(start, end time.Time) bool {
+	return e.Date.Before(end) && e.End().After(start)
+}
+
+// EventInput содержит данные события, задаваемые пользователем при создании и изменении
+type EventInput struct {
+	Date        time.Time
+	EndDate     time.Time
+	Text        string
+	Title       string
+	Description string
+	Location    *Location
+	URL         string
+	Properties  map[string]string
+	Tags        []string
+}
+
+// ConflictPolicy определяет реакцию на пересечение события с существующими
+type ConflictPolicy string
+
+const (
+	// ConflictAllow - пересечения не проверяются
+	ConflictAllow ConflictPolicy = "allow"
+	// ConflictWarn - событие сохраняется, пересечения возвращаются вместе с ним
+	ConflictWarn ConflictPolicy = "warn"
+	// ConflictReject - событие с пересечениями не сохраняется
+	ConflictReject ConflictPolicy = "reject"
+)
+
+// EventRepository определяет интерфейс для работы с событиями.
+// Выборки возвращают события, упорядоченные по дате начала, а при равенстве - по ID.
+// WithTx выполняет fn как единицу работы: tx видит изменения, сделанные внутри fn, другие запросы
+// не вмешиваются между чтениями и записями fn, а при ошибке fn все ее изменения откатываются.
+// Вызов WithTx у tx выполняет fn в той же транзакции.
+// Методы прерываются с ошибкой ctx.Err(), если контекст отменен.
 type EventRepository interface {
-	Create(event *Event) error
-	Update(event *Event) error
-	Delete(id int, userID int) error
-	GetByID(id int) (*Event, error)
-	GetByUserAndDate(userID int, date time.Time) ([]*Event, error)
-	GetByUserAndDateRange(userID int, startDate, endDate time.Time) ([]*Event, error)
+	Create(ctx context.Context, event *Event) error
+	Update(ctx context.Context, event *Event) error
+	Delete(ctx context.Context, id int, userID int) error
+	GetByID(ctx context.Context, id int) (*Event, error)
+	GetByUserAndDate(ctx context.Context, userID int, date time.Time) ([]*Event, error)
+	GetByUserAndDateRange(ctx context.Context, userID int, startDate, endDate time.Time) ([]*Event, error)
+	GetOverlapping(ctx context.Context, userID int, start, end time.Time) ([]*Event, error)
+	ListByUserAndDateRange(ctx context.Context, userID int, startDate, endDate time.Time, page PageRequest) (*EventPage, error)
+	Search(ctx context.Context, userID int, query string, limit int) ([]*SearchResult, error)
+	ReplaceTag(ctx context.Context, userID int, oldName, newName string) error
+	WithTx(ctx context.Context, fn func(tx EventRepository) error) error
 }
 
-// EventService определяет бизнес-логику для работы с событиями
+// EventService определяет бизнес-логику для работы с событиями.
+// Контекст запроса передается в репозиторий, и его отмена прерывает выполнение.
 type EventService interface {
-	CreateEvent(userID int, date time.Time, text string) (*Event, error)
-	UpdateEvent(id int, userID int, date time.Time, text string) (*Event, error)
-	DeleteEvent(id int, userID int) error
-	GetEventsForDay(userID int, date time.Time) ([]*Event, error)
-	GetEventsForWeek(userID int, startDate time.Time) ([]*Event, error)
-	GetEventsForMonth(userID int, yearMonth time.Time) ([]*Event, error)
+	CreateEvent(ctx context.Context, userID int, input EventInput, policy ConflictPolicy) (*Event, []*Event, error)
+	UpdateEvent(ctx context.Context, id int, userID int, input EventInput, policy ConflictPolicy) (*Event, []*Event, error)
+	DeleteEvent(ctx context.Context, id int, userID int) error
+	ApplyBatch(ctx context.Context, userID int, operations []BatchOperation, policy ConflictPolicy, atomic bool) (*BatchResult, error)
+	GetEventsForDay(ctx context.Context, userID int, date time.Time, page PageRequest) (*EventPage, error)
+	GetEventsForWeek(ctx context.Context, userID int, startDate time.Time, page PageRequest) (*EventPage, error)
+	GetEventsForCalendarWeek(ctx context.Context, userID int, date time.Time, weekStart time.Weekday, page PageRequest) (*EventPage, error)
+	GetEventsForMonth(ctx context.Context, userID int, yearMonth time.Time, page PageRequest) (*EventPage, error)
+	GetEventsForRange(ctx context.Context, userID int, from, to time.Time, page PageRequest) (*EventPage, error)
+	GetEventsForYear(ctx context.Context, userID int, year time.Time) ([]*MonthSummary, error)
+	GetAgenda(ctx context.Context, userID int, from time.Time, page PageRequest) (*EventPage, error)
+	SearchEvents(ctx context.Context, userID int, query string, limit int) ([]*SearchResult, error)
+	QuickAdd(ctx context.Context, userID int, phrase string, loc *time.Location, save bool, policy ConflictPolicy) (*QuickAddResult, []*Event, error)
+	GetFreeBusy(ctx context.Context, userIDs []int, from, to time.Time) ([]*FreeBusy, error)
+	FindSlots(ctx context.Context, query SlotQuery) ([]*Slot, error)
+}
+
+// MonthSummary представляет события одного месяца в годовом обзоре
+type MonthSummary struct {
+	Month  string   `json:"month"`
+	Count  int      `json:"count"`
+	Events []*Event `json:"events"`
+}
+
+// QuickAddResult - интерпретация фразы быстрого добавления события
+type QuickAddResult struct {
+	Event      *Event   `json:"event"`
+	AllDay     bool     `json:"all_day"`
+	Recognized []string `json:"recognized"`
+	Saved      bool     `json:"saved"`
 }
@@ -0,0 +1,6 @@
+package domain
+
+// EventFilter отбирает события по произвольному условию
+type EventFilter interface {
+	Match(event *Event) bool
+}
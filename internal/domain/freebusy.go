@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+)
+
+// TimePeriod представляет полуинтервал времени [Start, End)
+type TimePeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// FreeBusy представляет занятость пользователя без раскрытия содержимого событий
+type FreeBusy struct {
+	UserID int          `json:"user_id"`
+	Busy   []TimePeriod `json:"busy"`
+}
+
+// SlotQuery описывает параметры поиска времени для встречи
+type SlotQuery struct {
+	Participants    []int
+	Duration        time.Duration
+	From            time.Time
+	To              time.Time
+	WorkDayStart    time.Duration // смещение начала рабочего дня от полуночи
+	WorkDayEnd      time.Duration // смещение конца рабочего дня от полуночи
+	IncludeWeekends bool
+	MinNotice       time.Duration
+	Quorum          int // минимальное число свободных участников
+	Step            time.Duration
+	Limit           int
+}
+
+// Slot представляет предложенное время встречи
+type Slot struct {
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Available   []int     `json:"available"`
+	Unavailable []int     `json:"unavailable"`
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// IdempotentResponse представляет сохраненный ответ на запрос с ключом идемпотентности
+type IdempotentResponse struct {
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+}
+
+// IdempotencyRecord представляет запись о ключе идемпотентности пользователя
+type IdempotencyRecord struct {
+	Fingerprint string              // отпечаток первого запроса: метод, путь и тело
+	Response    *IdempotentResponse // nil, пока первый запрос выполняется
+	ExpiresAt   time.Time
+}
+
+// IdempotencyStore определяет интерфейс хранилища ключей идемпотентности.
+// Ключи хранятся отдельно для каждого пользователя.
+type IdempotencyStore interface {
+	// Reserve резервирует ключ за запросом с отпечатком fingerprint и возвращает true.
+	// Если ключ уже занят, возвращает существующую запись и false.
+	Reserve(userID int, key, fingerprint string) (*IdempotencyRecord, bool)
+	// Complete сохраняет ответ на запрос с зарезервированным ключом
+	Complete(userID int, key string, response *IdempotentResponse)
+	// Release снимает резервирование, чтобы запрос с этим ключом можно было повторить
+	Release(userID int, key string)
+}
@@ -0,0 +1,28 @@
+package domain
+
+// EventSort определяет порядок сортировки событий
+type EventSort string
+
+const (
+	// SortByDate - по дате начала, затем по ID
+	SortByDate EventSort = "date"
+	// SortByUpdatedAt - по времени последнего изменения, затем по ID
+	SortByUpdatedAt EventSort = "updated_at"
+)
+
+// PageRequest описывает запрос страницы событий.
+// Limit равный нулю означает выборку без ограничения; Cursor - непрозрачная позиция,
+// возвращенная предыдущей страницей в EventPage.NextCursor. Filter, если задан,
+// применяется до разбиения на страницы.
+type PageRequest struct {
+	Sort   EventSort
+	Limit  int
+	Cursor string
+	Filter EventFilter
+}
+
+// EventPage представляет страницу событий
+type EventPage struct {
+	Events     []*Event `json:"events"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
@@ -2,8 +2,25 @@ package domain
 
 // Response представляет стандартный ответ API
 type Response struct {
-	Result interface{} `json:"result,omitempty"`
-	Error  string      `json:"error,omitempty"`
+	Result     interface{}  `json:"result,omitempty"`
+	Error      string       `json:"error,omitempty"`
+	Code       ErrorCode    `json:"code,omitempty"`
+	Details    []FieldError `json:"details,omitempty"`
+	Conflicts  []*Event     `json:"conflicts,omitempty"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+// ProblemDetails представляет ошибку в формате RFC 7807 (application/problem+json)
+type ProblemDetails struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      ErrorCode    `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	Conflicts []*Event     `json:"conflicts,omitempty"`
 }
 
 // CreateEventRequest представляет запрос на создание события
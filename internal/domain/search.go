@@ -0,0 +1,7 @@
+package domain
+
+// SearchResult представляет найденное событие с оценкой релевантности
+type SearchResult struct {
+	Event *Event  `json:"event"`
+	Score float64 `json:"score"`
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "context"
+
+// DefaultTagColor - цвет тега по умолчанию
+const DefaultTagColor = "#808080"
+
+// Tag представляет пользовательский тег с цветом
+type Tag struct {
+	ID     int    `json:"id"`
+	UserID int    `json:"user_id"`
+	Name   string `json:"name"`
+	Color  string `json:"color"`
+}
+
+// TagRepository определяет интерфейс для работы с тегами
+type TagRepository interface {
+	Create(tag *Tag) error
+	Update(tag *Tag) error
+	Delete(id int, userID int) error
+	GetByID(id int) (*Tag, error)
+	GetByUser(userID int) ([]*Tag, error)
+}
+
+// TagService определяет бизнес-логику для работы с тегами
+type TagService interface {
+	CreateTag(ctx context.Context, userID int, name, color string) (*Tag, error)
+	UpdateTag(ctx context.Context, id int, userID int, name, color string) (*Tag, error)
+	DeleteTag(ctx context.Context, id int, userID int) error
+	GetTags(ctx context.Context, userID int) ([]*Tag, error)
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	// DefaultTimeZone - часовой пояс пользователя по умолчанию
+	DefaultTimeZone = "UTC"
+	// DefaultWeekStart - первый день недели по умолчанию
+	DefaultWeekStart = "monday"
+)
+
+// Языки сообщений API
+const (
+	LanguageRussian = "ru"
+	LanguageEnglish = "en"
+	// DefaultLanguage - язык сообщений, если ни пользователь, ни клиент не выбрали поддерживаемый язык
+	DefaultLanguage = LanguageRussian
+)
+
+// IsSupportedLanguage сообщает, поддерживаются ли сообщения API на языке lang
+func IsSupportedLanguage(lang string) bool {
+	return lang == LanguageRussian || lang == LanguageEnglish
+}
+
+// weekStarts - допустимые первые дни недели
+var weekStarts = map[string]time.Weekday{
+	"monday":   time.Monday,
+	"sunday":   time.Sunday,
+	"saturday": time.Saturday,
+}
+
+// ParseWeekStart возвращает день недели по его названию в настройках
+func ParseWeekStart(name string) (time.Weekday, bool) {
+	weekday, ok := weekStarts[name]
+	return weekday, ok
+}
+
+// UserSettings представляет персональные настройки пользователя
+type UserSettings struct {
+	UserID    int    `json:"user_id"`
+	TimeZone  string `json:"time_zone"`
+	WeekStart string `json:"week_start"`
+	Language  string `json:"language,omitempty"` // язык сообщений API; пустой - по заголовку Accept-Language
+}
+
+// UserSettingsRepository определяет интерфейс для хранения настроек пользователей
+type UserSettingsRepository interface {
+	Get(userID int) (*UserSettings, error)
+	Save(settings *UserSettings) error
+}
+
+// UserSettingsService определяет бизнес-логику для работы с настройками пользователей
+type UserSettingsService interface {
+	GetSettings(userID int) (*UserSettings, error)
+	UpdateSettings(userID int, patch UserSettings) (*UserSettings, error)
+	Location(userID int) (*time.Location, error)
+	WeekStart(userID int) (time.Weekday, error)
+	Language(userID int) (string, error)
+}
@@ -0,0 +1,125 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Статусы проверки и отчета
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// CheckFunc проверяет зависимость и возвращает ошибку, если она недоступна.
+// Проверка должна завершаться после отмены ctx.
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult - результат одной проверки
+type CheckResult struct {
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	Error      string  `json:"error,omitempty"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+// Report - результаты всех проверок; Status равен ok, только если успешны все проверки
+type Report struct {
+	Status string         `json:"status"`
+	Checks []*CheckResult `json:"checks"`
+}
+
+// check - зарегистрированная проверка
+type check struct {
+	name    string
+	timeout time.Duration
+	fn      CheckFunc
+}
+
+// Registry хранит проверки готовности и выполняет их
+type Registry struct {
+	mu             sync.RWMutex
+	checks         []check
+	defaultTimeout time.Duration
+}
+
+// NewRegistry создает реестр проверок; defaultTimeout ограничивает проверки без собственного таймаута
+func NewRegistry(defaultTimeout time.Duration) *Registry {
+	return &Registry{defaultTimeout: defaultTimeout}
+}
+
+// Register добавляет проверку; timeout <= 0 означает таймаут реестра по умолчанию
+func (r *Registry) Register(name string, timeout time.Duration, fn CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if timeout <= 0 {
+		timeout = r.defaultTimeout
+	}
+	r.checks = append(r.checks, check{name: name, timeout: timeout, fn: fn})
+}
+
+// Run параллельно выполняет все проверки, каждую со своим таймаутом, и возвращает отчет
+// в порядке регистрации проверок
+func (r *Registry) Run(ctx context.Context) *Report {
+	r.mu.RLock()
+	checks := append([]check(nil), r.checks...)
+	r.mu.RUnlock()
+
+	report := &Report{Status: StatusOK, Checks: make([]*CheckResult, len(checks))}
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = runCheck(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+
+	return report
+}
+
+// runCheck выполняет проверку с таймаутом. Проверка, не реагирующая на отмену контекста,
+// считается неуспешной по истечении таймаута и дорабатывает в фоне.
+func runCheck(ctx context.Context, c check) *CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- c.fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := &CheckResult{
+		Name:       c.name,
+		Status:     StatusOK,
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+		if errors.Is(err, context.DeadlineExceeded) {
+			result.Error = "проверка не завершилась за " + c.timeout.String()
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,116 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Run(t *testing.T) {
+	ok := func(ctx context.Context) error { return nil }
+	failing := func(ctx context.Context) error { return errors.New("хранилище недоступно") }
+	// hanging игнорирует отмену контекста и завершается только после окончания теста
+	release := make(chan struct{})
+	defer close(release)
+	hanging := func(ctx context.Context) error {
+		<-release
+		return nil
+	}
+
+	tests := []struct {
+		name           string
+		register       func(r *Registry)
+		expectedStatus string
+		expectedChecks []CheckResult
+	}{
+		{
+			name:           "Без проверок",
+			register:       func(r *Registry) {},
+			expectedStatus: StatusOK,
+			expectedChecks: []CheckResult{},
+		},
+		{
+			name: "Все проверки успешны",
+			register: func(r *Registry) {
+				r.Register("a", 0, ok)
+				r.Register("b", 0, ok)
+			},
+			expectedStatus: StatusOK,
+			expectedChecks: []CheckResult{
+				{Name: "a", Status: StatusOK},
+				{Name: "b", Status: StatusOK},
+			},
+		},
+		{
+			name: "Ошибка одной проверки",
+			register: func(r *Registry) {
+				r.Register("a", 0, ok)
+				r.Register("b", 0, failing)
+			},
+			expectedStatus: StatusFail,
+			expectedChecks: []CheckResult{
+				{Name: "a", Status: StatusOK},
+				{Name: "b", Status: StatusFail, Error: "хранилище недоступно"},
+			},
+		},
+		{
+			name: "Проверка не уложилась в собственный таймаут",
+			register: func(r *Registry) {
+				r.Register("slow", 20*time.Millisecond, hanging)
+				r.Register("a", 0, ok)
+			},
+			expectedStatus: StatusFail,
+			expectedChecks: []CheckResult{
+				{Name: "slow", Status: StatusFail, Error: "проверка не завершилась за 20ms"},
+				{Name: "a", Status: StatusOK},
+			},
+		},
+		{
+			name: "Проверка не уложилась в таймаут по умолчанию",
+			register: func(r *Registry) {
+				r.Register("slow", 0, hanging)
+			},
+			expectedStatus: StatusFail,
+			expectedChecks: []CheckResult{
+				{Name: "slow", Status: StatusFail, Error: "проверка не завершилась за 50ms"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry(50 * time.Millisecond)
+			tt.register(registry)
+
+			report := registry.Run(context.Background())
+
+			assert.Equal(t, tt.expectedStatus, report.Status)
+			require.Len(t, report.Checks, len(tt.expectedChecks))
+			for i, expected := range tt.expectedChecks {
+				assert.Equal(t, expected.Name, report.Checks[i].Name)
+				assert.Equal(t, expected.Status, report.Checks[i].Status)
+				assert.Equal(t, expected.Error, report.Checks[i].Error)
+			}
+		})
+	}
+}
+
+func TestRegistry_RunCanceled(t *testing.T) {
+	registry := NewRegistry(time.Second)
+	registry.Register("ctx", 0, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := registry.Run(ctx)
+
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Equal(t, context.Canceled.Error(), report.Checks[0].Error)
+}
@@ -0,0 +1,280 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"calendar/internal/domain"
+)
+
+// contextKey - тип ключей контекста пакета
+type contextKey int
+
+const languageKey contextKey = iota
+
+// message - сообщение об ошибке с определенным кодом на одном языке
+type message struct {
+	text  string // краткое описание ошибки
+	field string // шаблон сообщения об ошибке в поле запроса; %s заменяется именем поля
+}
+
+// catalog - сообщения об ошибках по языкам и кодам ошибок
+var catalog = map[string]map[domain.ErrorCode]message{
+	domain.LanguageRussian: {
+		domain.CodeValidation:            {text: "Некорректный запрос", field: "некорректное значение %s"},
+		domain.CodeMissingParameter:      {text: "Не указан обязательный параметр", field: "параметр %s обязателен"},
+		domain.CodeInvalidParameter:      {text: "Некорректное значение параметра", field: "некорректное значение параметра %s"},
+		domain.CodeInvalidDate:           {text: "Некорректная дата", field: "некорректная дата в параметре %s"},
+		domain.CodeInvalidJSON:           {text: "Некорректное JSON-тело запроса"},
+		domain.CodeInvalidFilter:         {text: "Некорректное выражение фильтра", field: "некорректное выражение фильтра в параметре %s"},
+		domain.CodeInvalidCursor:         {text: "Некорректный курсор страницы", field: "некорректный курсор страницы в параметре %s"},
+		domain.CodeInvalidPhrase:         {text: "Не удалось распознать фразу", field: "не удалось распознать фразу в параметре %s"},
+		domain.CodeInvalidIdempotencyKey: {text: "Некорректный ключ идемпотентности"},
+		domain.CodeRequestTooLarge:       {text: "Тело запроса превышает допустимый размер"},
+		domain.CodeUnauthorized:          {text: "Требуется аутентификация"},
+		domain.CodeAccessDenied:          {text: "Нет прав для выполнения операции"},
+		domain.CodeNotFound:              {text: "Объект не найден"},
+		domain.CodeEventNotFound:         {text: "Событие не найдено"},
+		domain.CodeTagNotFound:           {text: "Тег не найден", field: "тег из параметра %s не найден"},
+		domain.CodeSettingsNotFound:      {text: "Настройки пользователя не найдены"},
+		domain.CodeConflict:              {text: "Конфликт с текущим состоянием"},
+		domain.CodeEventConflict:         {text: "Событие пересекается с существующими событиями"},
+		domain.CodeTagAlreadyExists:      {text: "Тег с таким названием уже существует", field: "тег с названием из параметра %s уже существует"},
+		domain.CodeIdempotencyKeyReused:  {text: "Ключ идемпотентности уже использован для другого запроса"},
+		domain.CodeIdempotencyInProgress: {text: "Запрос с этим ключом идемпотентности еще выполняется"},
+		domain.CodeBusinessLogic:         {text: "Операция не может быть выполнена"},
+		domain.CodeRequestCanceled:       {text: "Запрос отменен или превысил время ожидания"},
+		domain.CodeInternal:              {text: "Внутренняя ошибка сервера"},
+		domain.CodeUnprocessable:         {text: "Запрос не может быть обработан"},
+		domain.CodeFailedDependency:      {text: "Операция не выполнена из-за ошибок в других операциях пакета"},
+		domain.CodeServiceUnavailable:    {text: "Сервис временно недоступен"},
+		domain.CodeUnknown:               {text: "Неизвестная ошибка"},
+	},
+	domain.LanguageEnglish: {
+		domain.CodeValidation:            {text: "Invalid request", field: "invalid value of %s"},
+		domain.CodeMissingParameter:      {text: "Required parameter is missing", field: "parameter %s is required"},
+		domain.CodeInvalidParameter:      {text: "Invalid parameter value", field: "invalid value of parameter %s"},
+		domain.CodeInvalidDate:           {text: "Invalid date", field: "invalid date in parameter %s"},
+		domain.CodeInvalidJSON:           {text: "Invalid JSON request body"},
+		domain.CodeInvalidFilter:         {text: "Invalid filter expression", field: "invalid filter expression in parameter %s"},
+		domain.CodeInvalidCursor:         {text: "Invalid page cursor", field: "invalid page cursor in parameter %s"},
+		domain.CodeInvalidPhrase:         {text: "Could not understand the phrase", field: "could not understand the phrase in parameter %s"},
+		domain.CodeInvalidIdempotencyKey: {text: "Invalid idempotency key"},
+		domain.CodeRequestTooLarge:       {text: "Request body is too large"},
+		domain.CodeUnauthorized:          {text: "Authentication required"},
+		domain.CodeAccessDenied:          {text: "Access denied"},
+		domain.CodeNotFound:              {text: "Not found"},
+		domain.CodeEventNotFound:         {text: "Event not found"},
+		domain.CodeTagNotFound:           {text: "Tag not found", field: "tag from parameter %s not found"},
+		domain.CodeSettingsNotFound:      {text: "User settings not found"},
+		domain.CodeConflict:              {text: "Conflict with the current state"},
+		domain.CodeEventConflict:         {text: "Event overlaps existing events"},
+		domain.CodeTagAlreadyExists:      {text: "A tag with this name already exists", field: "a tag with the name from parameter %s already exists"},
+		domain.CodeIdempotencyKeyReused:  {text: "Idempotency key has already been used for a different request"},
+		domain.CodeIdempotencyInProgress: {text: "A request with this idempotency key is still in progress"},
+		domain.CodeBusinessLogic:         {text: "The operation cannot be performed"},
+		domain.CodeRequestCanceled:       {text: "Request was canceled or timed out"},
+		domain.CodeInternal:              {text: "Internal server error"},
+		domain.CodeUnprocessable:         {text: "The request cannot be processed"},
+		domain.CodeFailedDependency:      {text: "Operation was not applied because other operations in the batch failed"},
+		domain.CodeServiceUnavailable:    {text: "Service temporarily unavailable"},
+		domain.CodeUnknown:               {text: "Unknown error"},
+	},
+}
+
+// details - шаблоны подробных сообщений об ошибках в полях по языкам; {field} заменяется именем поля,
+// остальные {name} - параметрами ошибки. Русские сообщения приложения уже содержат эти подробности,
+// поэтому шаблоны нужны только для других языков.
+var details = map[string]map[domain.MessageKey]string{
+	domain.LanguageEnglish: {
+		domain.MsgFilterEmpty:             "filter must not be empty",
+		domain.MsgFilterTooLong:           "filter is longer than {max} characters",
+		domain.MsgFilterUnexpectedToken:   `filter error at position {position}: unexpected token "{token}"`,
+		domain.MsgFilterUnexpectedEnd:     "filter error at position {position}: unexpected end of filter",
+		domain.MsgFilterExpectedParen:     `filter error at position {position}: expected a closing parenthesis, got "{token}"`,
+		domain.MsgFilterExpectedCondition: `filter error at position {position}: expected a condition, got "{token}"`,
+		domain.MsgFilterExpectedOperator:  `filter error at position {position}: expected a comparison operator after field "{name}", got "{token}"`,
+		domain.MsgFilterExpectedValue:     `filter error at position {position}: expected a value after operator "{operator}", got "{token}"`,
+		domain.MsgFilterExpectedNotEqual:  "filter error at position {position}: expected operator !=",
+		domain.MsgFilterUnclosedQuote:     "filter error at position {position}: unclosed quote",
+		domain.MsgFilterUnknownField:      `filter error at position {position}: unknown field "{token}", available: {fields}`,
+		domain.MsgFilterUnsupportedOp:     `filter error at position {position}: operator "{operator}" is not supported for this field`,
+		domain.MsgFilterExpectedInteger:   `filter error at position {position}: expected an integer, got "{token}"`,
+		domain.MsgFilterInvalidDate:       `filter error at position {position}: invalid date "{token}", use YYYY-MM-DD or YYYY-MM-DDTHH:MM`,
+		domain.MsgEndBeforeStart:          "parameter {field} must not be earlier than {start}",
+		domain.MsgPeriodStartAfterEnd:     "parameter {start} must be earlier than {end}",
+		domain.MsgTooLong:                 "parameter {field} is longer than {max} characters",
+		domain.MsgLimitOutOfRange:         "parameter {field} must be between 1 and {max}",
+		domain.MsgUnknownTimeZone:         `unknown time zone "{value}" in parameter {field}`,
+		domain.MsgUnknownBatchOperation:   `unknown operation "{value}" in parameter {field}, use create, update or delete`,
+		domain.MsgTagNotFound:             `tag "{name}" from parameter {field} not found`,
+		domain.MsgTagAlreadyExists:        `a tag named "{name}" already exists`,
+	},
+}
+
+// Notice - ключ сообщения об успешно выполненной операции
+type Notice string
+
+// Сообщения об успешно выполненных операциях
+const (
+	NoticeEventDeleted Notice = "event_deleted"
+	NoticeTagDeleted   Notice = "tag_deleted"
+)
+
+// notices - сообщения об успешно выполненных операциях по языкам
+var notices = map[string]map[Notice]string{
+	domain.LanguageRussian: {
+		NoticeEventDeleted: "Событие успешно удалено",
+		NoticeTagDeleted:   "Тег успешно удален",
+	},
+	domain.LanguageEnglish: {
+		NoticeEventDeleted: "Event deleted successfully",
+		NoticeTagDeleted:   "Tag deleted successfully",
+	},
+}
+
+// WithLanguage возвращает контекст с языком сообщений API
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey, lang)
+}
+
+// Language возвращает язык сообщений API из контекста; язык по умолчанию, если его нет
+func Language(ctx context.Context) string {
+	if lang, _ := ctx.Value(languageKey).(string); lang != "" {
+		return lang
+	}
+	return domain.DefaultLanguage
+}
+
+// Negotiate выбирает поддерживаемый язык по заголовку Accept-Language с учетом весов q.
+// Региональные варианты сводятся к основному языку (en-GB - en). Если ни один язык
+// не поддерживается, возвращается пустая строка.
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		lang string
+		q    float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if !domain.IsSupportedLanguage(lang) {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{lang: lang, q: q})
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	// При равных весах предпочитается язык, указанный раньше
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].lang
+}
+
+// Title возвращает краткое описание ошибки с кодом code на языке lang.
+// Для неизвестного языка используется язык по умолчанию, для неизвестного кода - описание
+// неизвестной ошибки.
+func Title(lang string, code domain.ErrorCode) string {
+	return lookup(lang, code).text
+}
+
+// FieldMessage возвращает сообщение об ошибке с кодом code в поле field на языке lang
+func FieldMessage(lang string, code domain.ErrorCode, field string) string {
+	msg := lookup(lang, code)
+	if msg.field == "" {
+		return msg.text
+	}
+	return fmt.Sprintf(msg.field, field)
+}
+
+// DetailedFieldMessage возвращает сообщение об ошибке в поле на языке lang. Если для ключа
+// подробного сообщения есть шаблон, в него подставляются имя поля и параметры ошибки,
+// иначе сообщение строится по коду ошибки.
+func DetailedFieldMessage(lang string, field domain.FieldError) string {
+	template, ok := details[lang][field.Key]
+	if !ok {
+		return FieldMessage(lang, field.Code, field.Field)
+	}
+
+	replacements := []string{"{field}", field.Field}
+	for name, value := range field.Params {
+		replacements = append(replacements, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// Localize возвращает сообщение об ошибке и ошибки в полях на языке lang.
+// Исходные сообщения ошибок приложения написаны на русском, поэтому на русском они
+// возвращаются без изменений; на других языках сообщения собираются из каталога по ключам
+// подробных сообщений с параметрами ошибки, а без ключа - по коду ошибки и именам полей.
+// Причина ошибки (appErr.Err) в сообщение не попадает ни на каком языке.
+func Localize(lang string, appErr *domain.AppError) (string, []domain.FieldError) {
+	if !domain.IsSupportedLanguage(lang) || lang == domain.LanguageRussian {
+		if appErr.Message == "" {
+			return Title(domain.DefaultLanguage, appErr.ErrorCode()), appErr.Fields
+		}
+		return appErr.Message, appErr.Fields
+	}
+
+	code := appErr.ErrorCode()
+	if len(appErr.Fields) == 0 {
+		return Title(lang, code), nil
+	}
+
+	fields := make([]domain.FieldError, len(appErr.Fields))
+	for i, field := range appErr.Fields {
+		fields[i] = domain.FieldError{
+			Field:   field.Field,
+			Code:    field.Code,
+			Message: DetailedFieldMessage(lang, field),
+		}
+	}
+
+	// Общее сообщение об ошибке в нескольких полях (например, from и to) подходит и ко всей ошибке
+	text := fields[0].Message
+	for _, field := range fields[1:] {
+		if field.Message != text {
+			text = Title(lang, code)
+			break
+		}
+	}
+	return text, fields
+}
+
+// NoticeMessage возвращает сообщение об успешно выполненной операции на языке lang;
+// для неизвестного языка используется язык по умолчанию
+func NoticeMessage(lang string, notice Notice) string {
+	messages, ok := notices[lang]
+	if !ok {
+		messages = notices[domain.DefaultLanguage]
+	}
+	return messages[notice]
+}
+
+// lookup находит сообщение в каталоге
+func lookup(lang string, code domain.ErrorCode) message {
+	messages, ok := catalog[lang]
+	if !ok {
+		messages = catalog[domain.DefaultLanguage]
+	}
+	if msg, ok := messages[code]; ok {
+		return msg
+	}
+	return messages[domain.CodeUnknown]
+}
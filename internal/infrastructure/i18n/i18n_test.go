@@ -0,0 +1,187 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	"calendar/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalog_Complete(t *testing.T) {
+	codes := []domain.ErrorCode{
+		domain.CodeValidation, domain.CodeMissingParameter, domain.CodeInvalidParameter, domain.CodeInvalidDate,
+		domain.CodeInvalidJSON, domain.CodeInvalidFilter, domain.CodeInvalidCursor, domain.CodeInvalidPhrase,
+		domain.CodeInvalidIdempotencyKey, domain.CodeRequestTooLarge, domain.CodeUnauthorized, domain.CodeAccessDenied,
+		domain.CodeNotFound, domain.CodeEventNotFound, domain.CodeTagNotFound, domain.CodeSettingsNotFound,
+		domain.CodeConflict, domain.CodeEventConflict, domain.CodeTagAlreadyExists, domain.CodeIdempotencyKeyReused,
+		domain.CodeIdempotencyInProgress, domain.CodeBusinessLogic, domain.CodeRequestCanceled, domain.CodeInternal,
+		domain.CodeUnprocessable, domain.CodeFailedDependency, domain.CodeServiceUnavailable, domain.CodeUnknown,
+	}
+
+	for _, lang := range []string{domain.LanguageRussian, domain.LanguageEnglish} {
+		assert.Len(t, catalog[lang], len(codes), lang)
+		for _, code := range codes {
+			msg, ok := catalog[lang][code]
+			if assert.True(t, ok, "%s: нет сообщения для %s", lang, code) {
+				assert.NotEmpty(t, msg.text, "%s: %s", lang, code)
+			}
+		}
+	}
+}
+
+func TestDetails_Complete(t *testing.T) {
+	keys := []domain.MessageKey{
+		domain.MsgFilterEmpty, domain.MsgFilterTooLong, domain.MsgFilterUnexpectedToken, domain.MsgFilterUnexpectedEnd,
+		domain.MsgFilterExpectedParen, domain.MsgFilterExpectedCondition, domain.MsgFilterExpectedOperator,
+		domain.MsgFilterExpectedValue, domain.MsgFilterExpectedNotEqual, domain.MsgFilterUnclosedQuote,
+		domain.MsgFilterUnknownField, domain.MsgFilterUnsupportedOp, domain.MsgFilterExpectedInteger,
+		domain.MsgFilterInvalidDate, domain.MsgEndBeforeStart, domain.MsgPeriodStartAfterEnd, domain.MsgTooLong,
+		domain.MsgLimitOutOfRange, domain.MsgUnknownTimeZone, domain.MsgUnknownBatchOperation,
+		domain.MsgTagNotFound, domain.MsgTagAlreadyExists,
+	}
+
+	assert.Len(t, details[domain.LanguageEnglish], len(keys))
+	for _, key := range keys {
+		assert.NotEmpty(t, details[domain.LanguageEnglish][key], "нет шаблона для %s", key)
+	}
+
+	for _, lang := range []string{domain.LanguageRussian, domain.LanguageEnglish} {
+		for _, notice := range []Notice{NoticeEventDeleted, NoticeTagDeleted} {
+			assert.NotEmpty(t, NoticeMessage(lang, notice), "%s: нет сообщения %s", lang, notice)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{name: "Без заголовка", acceptLanguage: "", expected: ""},
+		{name: "Английский", acceptLanguage: "en", expected: "en"},
+		{name: "Региональный вариант", acceptLanguage: "en-GB", expected: "en"},
+		{name: "Регистр не важен", acceptLanguage: "RU-ru", expected: "ru"},
+		{name: "Выбор по весу", acceptLanguage: "ru;q=0.5, en;q=0.9", expected: "en"},
+		{name: "При равных весах первый", acceptLanguage: "en, ru", expected: "en"},
+		{name: "Неподдерживаемые языки пропускаются", acceptLanguage: "de-DE, fr;q=0.9, en;q=0.1", expected: "en"},
+		{name: "Только неподдерживаемые языки", acceptLanguage: "de, *", expected: ""},
+		{name: "Нулевой вес исключает язык", acceptLanguage: "en;q=0, ru;q=0.2", expected: "ru"},
+		{name: "Некорректный вес", acceptLanguage: "en;q=abc, ru", expected: "ru"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Negotiate(tt.acceptLanguage))
+		})
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	assert.Equal(t, domain.DefaultLanguage, Language(context.Background()))
+	assert.Equal(t, domain.LanguageEnglish, Language(WithLanguage(context.Background(), domain.LanguageEnglish)))
+}
+
+func TestTitle_Fallback(t *testing.T) {
+	assert.Equal(t, "Event not found", Title(domain.LanguageEnglish, domain.CodeEventNotFound))
+	// Неизвестный язык - язык по умолчанию, неизвестный код - неизвестная ошибка
+	assert.Equal(t, "Событие не найдено", Title("de", domain.CodeEventNotFound))
+	assert.Equal(t, "Unknown error", Title(domain.LanguageEnglish, "NO_SUCH_CODE"))
+}
+
+func TestLocalize(t *testing.T) {
+	dateErr := domain.NewFieldError("date", domain.CodeInvalidDate, "некорректный формат даты, используйте YYYY-MM-DD")
+
+	// На русском сохраняются исходные, более подробные сообщения
+	message, fields := Localize(domain.LanguageRussian, dateErr)
+	assert.Equal(t, "некорректный формат даты, используйте YYYY-MM-DD", message)
+	assert.Equal(t, dateErr.Fields, fields)
+
+	message, fields = Localize(domain.LanguageEnglish, dateErr)
+	assert.Equal(t, "invalid date in parameter date", message)
+	require.Len(t, fields, 1)
+	assert.Equal(t, domain.FieldError{Field: "date", Code: domain.CodeInvalidDate, Message: "invalid date in parameter date"}, fields[0])
+	// Исходная ошибка не меняется
+	assert.Equal(t, "некорректный формат даты, используйте YYYY-MM-DD", dateErr.Fields[0].Message)
+
+	// Несколько полей - общее описание ошибки
+	pairErr := domain.NewFieldError("lat", domain.CodeInvalidParameter, "параметры lat и lon задаются вместе").WithField("lon")
+	message, fields = Localize(domain.LanguageEnglish, pairErr)
+	assert.Equal(t, "Invalid parameter value", message)
+	require.Len(t, fields, 2)
+	assert.Equal(t, "invalid value of parameter lon", fields[1].Message)
+
+	// Без полей - описание по коду; причина внутренней ошибки не раскрывается ни на каком языке
+	internalErr := domain.NewInternalError("ошибка при создании события", assert.AnError)
+	message, fields = Localize(domain.LanguageEnglish, internalErr)
+	assert.Equal(t, "Internal server error", message)
+	assert.Nil(t, fields)
+
+	message, _ = Localize(domain.LanguageRussian, internalErr)
+	assert.Equal(t, "ошибка при создании события", message)
+
+	// Ошибка без сообщения - описание по коду
+	message, _ = Localize(domain.LanguageRussian, &domain.AppError{StatusCode: domain.StatusNotFound})
+	assert.Equal(t, "Объект не найден", message)
+}
+
+func TestLocalize_Details(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             *domain.AppError
+		expectedMessage string
+		expectedFields  []string
+	}{
+		{
+			name: "Позиция и лексема в фильтре",
+			err: domain.NewFieldError("filter", domain.CodeInvalidFilter, "ошибка в фильтре на позиции 7: неизвестное поле").
+				WithDetails(domain.MsgFilterUnknownField, domain.MessageParams{"position": "7", "token": "titel", "fields": "text, title"}),
+			expectedMessage: `filter error at position 7: unknown field "titel", available: text, title`,
+		},
+		{
+			name: "Поле, с которым сравнивается значение",
+			err: domain.NewFieldError("operations[2].end_date", domain.CodeInvalidDate, "дата окончания события раньше даты начала").
+				WithDetails(domain.MsgEndBeforeStart, domain.MessageParams{"start": "date"}),
+			expectedMessage: "parameter operations[2].end_date must not be earlier than date",
+		},
+		{
+			name: "Одинаковое сообщение в нескольких полях",
+			err: domain.NewFieldError("from", domain.CodeInvalidDate, "начало периода должно быть раньше его окончания").WithField("to").
+				WithDetails(domain.MsgPeriodStartAfterEnd, domain.MessageParams{"start": "from", "end": "to"}),
+			expectedMessage: "parameter from must be earlier than to",
+			expectedFields:  []string{"parameter from must be earlier than to", "parameter from must be earlier than to"},
+		},
+		{
+			name:            "Ключ без шаблона - сообщение по коду",
+			err:             domain.NewFieldError("q", domain.CodeInvalidParameter, "поисковый запрос слишком длинный").WithDetails("no.such.key", nil),
+			expectedMessage: "invalid value of parameter q",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, fields := Localize(domain.LanguageEnglish, tt.err)
+			assert.Equal(t, tt.expectedMessage, message)
+			if tt.expectedFields != nil {
+				require.Len(t, fields, len(tt.expectedFields))
+				for i, expected := range tt.expectedFields {
+					assert.Equal(t, expected, fields[i].Message)
+				}
+			}
+
+			// На русском подробное исходное сообщение не меняется
+			message, _ = Localize(domain.LanguageRussian, tt.err)
+			assert.Equal(t, tt.err.Message, message)
+		})
+	}
+}
+
+func TestNoticeMessage(t *testing.T) {
+	assert.Equal(t, "Событие успешно удалено", NoticeMessage(domain.LanguageRussian, NoticeEventDeleted))
+	assert.Equal(t, "Tag deleted successfully", NoticeMessage(domain.LanguageEnglish, NoticeTagDeleted))
+	// Неизвестный язык - язык по умолчанию
+	assert.Equal(t, "Тег успешно удален", NoticeMessage("de", NoticeTagDeleted))
+}
@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Форматы вывода журнала
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// contextKey - тип ключей контекста пакета
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	userIDKey
+)
+
+// New создает логгер с выводом в w в формате format (json или text).
+// Каждая запись дополняется ID запроса, пользователя и трассы из контекста, если они там есть.
+func New(w io.Writer, format string, level slog.Level) (*slog.Logger, error) {
+	options := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, options)
+	case FormatText:
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("неизвестный формат журнала %q, допустимы %s и %s", format, FormatJSON, FormatText)
+	}
+
+	return slog.New(contextHandler{handler}), nil
+}
+
+// WithRequestID возвращает контекст с ID запроса
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID возвращает ID запроса из контекста; пустую строку, если его нет
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// WithUserID возвращает контекст с ID пользователя, от имени которого выполняется запрос
+func WithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// UserID возвращает ID пользователя из контекста; 0, если его нет
+func UserID(ctx context.Context) int {
+	userID, _ := ctx.Value(userIDKey).(int)
+	return userID
+}
+
+// contextHandler добавляет к записям журнала атрибуты запроса из контекста
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle дополняет запись ID запроса, пользователя и трассы
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if userID := UserID(ctx); userID > 0 {
+		record.AddAttrs(slog.Int("user_id", userID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs возвращает обработчик с дополнительными атрибутами
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup возвращает обработчик с группой атрибутов
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,47 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_ContextAttributes(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := New(&out, FormatJSON, slog.LevelInfo)
+	assert.NoError(t, err)
+
+	ctx := WithUserID(WithRequestID(context.Background(), "req-1"), 7)
+	logger.With("component", "test").InfoContext(ctx, "событие создано", "event_id", 3)
+	logger.DebugContext(ctx, "не попадает в журнал")
+
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, "событие создано", record["msg"])
+	assert.Equal(t, "req-1", record["request_id"])
+	assert.Equal(t, float64(7), record["user_id"])
+	assert.Equal(t, float64(3), record["event_id"])
+	assert.Equal(t, "test", record["component"])
+
+	// Без атрибутов в контексте запись их не содержит
+	out.Reset()
+	logger.Info("сервер запущен")
+	assert.NotContains(t, out.String(), "request_id")
+	assert.NotContains(t, out.String(), "user_id")
+}
+
+func TestNew_Formats(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := New(&out, FormatText, slog.LevelInfo)
+	assert.NoError(t, err)
+
+	logger.InfoContext(WithRequestID(context.Background(), "req-2"), "запрос обработан")
+	assert.Contains(t, out.String(), "request_id=req-2")
+
+	_, err = New(&out, "xml", slog.LevelInfo)
+	assert.Error(t, err)
+}
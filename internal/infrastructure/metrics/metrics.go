@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"calendar/internal/domain"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace - префикс имен метрик сервиса
+const namespace = "calendar"
+
+// EventCounter - хранилище событий, умеющее сообщить их количество
+type EventCounter interface {
+	Count() int
+}
+
+// Metrics содержит метрики сервиса и собственный реестр, из которого они отдаются
+type Metrics struct {
+	registry  *prometheus.Registry
+	requests  *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+	appErrors *prometheus.CounterVec
+}
+
+// New создает метрики сервиса вместе с метриками среды выполнения Go и процесса
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Количество обработанных HTTP-запросов по маршруту, методу и статусу.",
+		}, []string{"route", "method", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Время обработки HTTP-запросов по маршруту, методу и статусу.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		appErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "app_errors_total",
+			Help:      "Количество ошибок приложения, возвращенных клиентам, по виду ошибки.",
+		}, []string{"kind"}),
+	}
+
+	m.registry.MustRegister(
+		m.requests,
+		m.duration,
+		m.appErrors,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// Handler возвращает HTTP-обработчик, отдающий метрики в текстовом формате Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// RegisterEventRepository добавляет метрику количества событий в хранилище backend
+func (m *Metrics) RegisterEventRepository(backend string, repo EventCounter) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "events",
+		Help:        "Количество событий в хранилище.",
+		ConstLabels: prometheus.Labels{"backend": backend},
+	}, func() float64 {
+		return float64(repo.Count())
+	}))
+}
+
+// ObserveRequest учитывает обработанный HTTP-запрос; route - шаблон маршрута, а не фактический путь
+func (m *Metrics) ObserveRequest(route, method string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	m.requests.WithLabelValues(route, method, code).Inc()
+	m.duration.WithLabelValues(route, method, code).Observe(duration.Seconds())
+}
+
+// ObserveAppError учитывает ошибку приложения, возвращенную клиенту
+func (m *Metrics) ObserveAppError(err *domain.AppError) {
+	m.appErrors.WithLabelValues(errorKind(err)).Inc()
+}
+
+// errorKind возвращает вид ошибки приложения по ее коду и статус-коду
+func errorKind(err *domain.AppError) string {
+	if err.ErrorCode() == domain.CodeBusinessLogic {
+		return "business_logic"
+	}
+
+	switch err.GetStatusCode() {
+	case domain.StatusBadRequest:
+		return "validation"
+	case domain.StatusUnauthorized:
+		return "unauthorized"
+	case domain.StatusForbidden:
+		return "access_denied"
+	case domain.StatusNotFound:
+		return "not_found"
+	case domain.StatusConflict:
+		return "conflict"
+	case domain.StatusServiceUnavailable:
+		return "business_logic"
+	case domain.StatusInternalServerError:
+		return "internal"
+	default:
+		return "other"
+	}
+}
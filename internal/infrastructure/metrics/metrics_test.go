@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"calendar/internal/domain"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// fixedCounter - хранилище с заданным количеством событий
+type fixedCounter int
+
+func (c fixedCounter) Count() int {
+	return int(c)
+}
+
+func TestMetrics(t *testing.T) {
+	m := New()
+	m.RegisterEventRepository("memory", fixedCounter(3))
+
+	m.ObserveRequest("/create_event", "POST", 200, 15*time.Millisecond)
+	m.ObserveRequest("/create_event", "POST", 200, 25*time.Millisecond)
+	m.ObserveRequest("/create_event", "POST", 400, time.Millisecond)
+
+	m.ObserveAppError(domain.NewValidationError("некорректная дата"))
+	m.ObserveAppError(domain.NewAccessDeniedError("нет прав"))
+	m.ObserveAppError(domain.NewAccessDeniedError("нет прав"))
+	m.ObserveAppError(domain.NewBusinessLogicError("операция невозможна"))
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.requests.WithLabelValues("/create_event", "POST", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues("/create_event", "POST", "400")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.appErrors.WithLabelValues("validation")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.appErrors.WithLabelValues("access_denied")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.appErrors.WithLabelValues("business_logic")))
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := recorder.Body.String()
+	assert.Contains(t, body, `calendar_events{backend="memory"} 3`)
+	assert.Contains(t, body, `calendar_http_request_duration_seconds_count{method="POST",route="/create_event",status="200"} 2`)
+	assert.Contains(t, body, "go_goroutines")
+}
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"calendar/internal/infrastructure/search"
+	"context"
+	"strings"
+	"time"
+)
+
+// cancelCheckInterval - через сколько просмотренных событий проверяется отмена контекста
+const cancelCheckInterval = 1024
+
+// eventStore хранит события в памяти. Методы не синхронизированы:
+// вызывающий должен удерживать блокировку MemoryEventRepository.
+type eventStore struct {
+	events map[int]*domain.Event
+	users  map[int][]int // map[userID][]eventIDs
+	index  *search.Index // полнотекстовый индекс по тексту событий
+	nextID int
+}
+
+// newEventStore создает пустое хранилище событий
+func newEventStore() *eventStore {
+	return &eventStore{
+		events: make(map[int]*domain.Event),
+		users:  make(map[int][]int),
+		index:  search.NewIndex(),
+		nextID: 1,
+	}
+}
+
+// Create создает новое событие
+func (s *eventStore) Create(ctx context.Context, event *domain.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	event.ID = s.nextID
+	s.nextID++
+	s.put(event)
+	return nil
+}
+
+// Update обновляет существующее событие
+func (s *eventStore) Update(ctx context.Context, event *domain.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if _, exists := s.events[event.ID]; !exists {
+		return domain.NewNotFoundError("событие не найдено").WithCode(domain.CodeEventNotFound)
+	}
+
+	s.set(event)
+	return nil
+}
+
+// Delete удаляет событие
+func (s *eventStore) Delete(ctx context.Context, id int, userID int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	event, exists := s.events[id]
+	if !exists {
+		return domain.NewNotFoundError("событие не найдено").WithCode(domain.CodeEventNotFound)
+	}
+
+	if event.UserID != userID {
+		return domain.NewAccessDeniedError("нет прав для удаления этого события")
+	}
+
+	s.remove(event)
+	return nil
+}
+
+// GetByID возвращает событие по ID
+func (s *eventStore) GetByID(ctx context.Context, id int) (*domain.Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	event, exists := s.events[id]
+	if !exists {
+		return nil, domain.NewNotFoundError("событие не найдено").WithCode(domain.CodeEventNotFound)
+	}
+
+	return event, nil
+}
+
+// GetByUserAndDate возвращает события пользователя на конкретную дату
+func (s *eventStore) GetByUserAndDate(ctx context.Context, userID int, date time.Time) ([]*domain.Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var events []*domain.Event
+	// Границы дня считаются по календарю: в дни перехода на летнее время в сутках 23 или 25 часов
+	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	endOfDay := startOfDay.AddDate(0, 0, 1)
+
+	for _, eventID := range s.users[userID] {
+		if event, exists := s.events[eventID]; exists {
+			if !event.Date.Before(startOfDay) && event.Date.Before(endOfDay) {
+				events = append(events, event)
+			}
+		}
+	}
+
+	sortEvents(events, domain.SortByDate)
+	return events, nil
+}
+
+// GetByUserAndDateRange возвращает события пользователя в указанном диапазоне дат
+func (s *eventStore) GetByUserAndDateRange(ctx context.Context, userID int, startDate, endDate time.Time) ([]*domain.Event, error) {
+	events, err := s.collectRange(ctx, userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	sortEvents(events, domain.SortByDate)
+	return events, nil
+}
+
+// GetOverlapping возвращает события пользователя, пересекающиеся с периодом [start, end),
+// включая начавшиеся раньше start длинные события
+func (s *eventStore) GetOverlapping(ctx context.Context, userID int, start, end time.Time) ([]*domain.Event, error) {
+	var events []*domain.Event
+
+	for i, eventID := range s.users[userID] {
+		if i%cancelCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if event, exists := s.events[eventID]; exists && event.Overlaps(start, end) {
+			events = append(events, event)
+		}
+	}
+
+	sortEvents(events, domain.SortByDate)
+	return events, nil
+}
+
+// ListByUserAndDateRange возвращает страницу событий пользователя в указанном диапазоне дат
+func (s *eventStore) ListByUserAndDateRange(ctx context.Context, userID int, startDate, endDate time.Time, page domain.PageRequest) (*domain.EventPage, error) {
+	events, err := s.collectRange(ctx, userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginate(events, page)
+}
+
+// Search ищет события пользователя по тексту с ранжированием по релевантности
+func (s *eventStore) Search(ctx context.Context, userID int, query string, limit int) ([]*domain.SearchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	hits := s.index.Search(userID, query, limit)
+	results := make([]*domain.SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		if event, exists := s.events[hit.ID]; exists {
+			results = append(results, &domain.SearchResult{Event: event, Score: hit.Score})
+		}
+	}
+
+	return results, nil
+}
+
+// ReplaceTag переименовывает тег во всех событиях пользователя; пустое newName снимает тег
+func (s *eventStore) ReplaceTag(ctx context.Context, userID int, oldName, newName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.replaceTag(userID, oldName, newName)
+	return nil
+}
+
+// replaceTag заменяет тег в событиях пользователя и возвращает прежние версии измененных событий.
+// Измененные события заменяются копиями, чтобы не затрагивать уже выданные указатели.
+func (s *eventStore) replaceTag(userID int, oldName, newName string) []*domain.Event {
+	var previous []*domain.Event
+
+	for _, eventID := range s.users[userID] {
+		event, exists := s.events[eventID]
+		if !exists || !event.HasTag(oldName) {
+			continue
+		}
+
+		tags := make([]string, 0, len(event.Tags))
+		for _, tag := range event.Tags {
+			switch {
+			case !strings.EqualFold(tag, oldName):
+				tags = append(tags, tag)
+			case newName != "":
+				tags = append(tags, newName)
+			}
+		}
+
+		updated := *event
+		updated.Tags = tags
+		s.events[eventID] = &updated
+		previous = append(previous, event)
+	}
+
+	return previous
+}
+
+// put добавляет событие с уже назначенным ID
+func (s *eventStore) put(event *domain.Event) {
+	s.events[event.ID] = event
+	s.users[event.UserID] = append(s.users[event.UserID], event.ID)
+	s.index.Add(event.ID, event.UserID, searchableText(event))
+}
+
+// set заменяет сохраненную версию события и обновляет индекс
+func (s *eventStore) set(event *domain.Event) {
+	s.events[event.ID] = event
+	s.index.Add(event.ID, event.UserID, searchableText(event))
+}
+
+// remove удаляет событие из хранилища, списка пользователя и индекса
+func (s *eventStore) remove(event *domain.Event) {
+	delete(s.events, event.ID)
+	s.index.Remove(event.ID)
+
+	// Удаляем из списка пользователя
+	if userEvents, ok := s.users[event.UserID]; ok {
+		for i, eventID := range userEvents {
+			if eventID == event.ID {
+				s.users[event.UserID] = append(userEvents[:i], userEvents[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// searchableText возвращает текст события для полнотекстового индекса: заголовок, текст, описание и место
+func searchableText(event *domain.Event) string {
+	return strings.Join([]string{event.Title, event.Text, event.Description, event.LocationText()}, " ")
+}
+
+// collectRange собирает события пользователя с датой начала в диапазоне [startDate, endDate].
+// Отмена контекста проверяется каждые cancelCheckInterval событий.
+func (s *eventStore) collectRange(ctx context.Context, userID int, startDate, endDate time.Time) ([]*domain.Event, error) {
+	var events []*domain.Event
+
+	for i, eventID := range s.users[userID] {
+		if i%cancelCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if event, exists := s.events[eventID]; exists {
+			if (event.Date.After(startDate) || event.Date.Equal(startDate)) &&
+				(event.Date.Before(endDate) || event.Date.Equal(endDate)) {
+				events = append(events, event)
+			}
+		}
+	}
+
+	return events, nil
+}
@@ -2,131 +2,161 @@ package repository
 
 import (
 	"calendar/internal/domain"
+	"context"
 	"sync"
 	"time"
 )
 
-// MemoryEventRepository реализует in-memory репозиторий для событий
+// pingRetryInterval - период повторных попыток получить блокировку в Ping
+const pingRetryInterval = 10 * time.Millisecond
+
+// MemoryEventRepository реализует in-memory репозиторий для событий.
+// Данные хранятся в eventStore, доступ к которому защищен блокировкой.
 type MemoryEventRepository struct {
-	events map[int]*domain.Event
-	users  map[int][]int // map[userID][]eventIDs
-	mu     sync.RWMutex
-	nextID int
+	store *eventStore
+	mu    sync.RWMutex
 }
 
 // NewMemoryEventRepository создает новый экземпляр in-memory репозитория
 func NewMemoryEventRepository() *MemoryEventRepository {
 	return &MemoryEventRepository{
-		events: make(map[int]*domain.Event),
-		users:  make(map[int][]int),
-		nextID: 1,
+		store: newEventStore(),
 	}
 }
 
 // Create создает новое событие
-func (r *MemoryEventRepository) Create(event *domain.Event) error {
+func (r *MemoryEventRepository) Create(ctx context.Context, event *domain.Event) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	event.ID = r.nextID
-	r.events[event.ID] = event
-	r.users[event.UserID] = append(r.users[event.UserID], event.ID)
-	r.nextID++
-
-	return nil
+	return r.store.Create(ctx, event)
 }
 
 // Update обновляет существующее событие
-func (r *MemoryEventRepository) Update(event *domain.Event) error {
+func (r *MemoryEventRepository) Update(ctx context.Context, event *domain.Event) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.events[event.ID]; !exists {
-		return domain.NewNotFoundError("событие не найдено")
-	}
-
-	r.events[event.ID] = event
-	return nil
+	return r.store.Update(ctx, event)
 }
 
 // Delete удаляет событие
-func (r *MemoryEventRepository) Delete(id int, userID int) error {
+func (r *MemoryEventRepository) Delete(ctx context.Context, id int, userID int) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	event, exists := r.events[id]
-	if !exists {
-		return domain.NewNotFoundError("событие не найдено")
-	}
+	return r.store.Delete(ctx, id, userID)
+}
 
-	if event.UserID != userID {
-		return domain.NewAccessDeniedError("нет прав для удаления этого события")
+// Ping проверяет доступность хранилища: блокировку, которую может удерживать долгая транзакция,
+// должно удаться получить до отмены ctx. Блокировка не ожидается, а запрашивается повторно,
+// чтобы по отмене ctx сразу вернуть ошибку и не оставлять ждущих горутин.
+func (r *MemoryEventRepository) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
-	// Удаляем событие
-	delete(r.events, id)
-
-	// Удаляем из списка пользователя
-	if userEvents, ok := r.users[userID]; ok {
-		for i, eventID := range userEvents {
-			if eventID == id {
-				r.users[userID] = append(userEvents[:i], userEvents[i+1:]...)
-				break
-			}
+	ticker := time.NewTicker(pingRetryInterval)
+	defer ticker.Stop()
+	for !r.mu.TryRLock() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
 		}
 	}
+	r.mu.RUnlock()
 
 	return nil
 }
 
-// GetByID возвращает событие по ID
-func (r *MemoryEventRepository) GetByID(id int) (*domain.Event, error) {
+// Count возвращает количество хранимых событий
+func (r *MemoryEventRepository) Count() int {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	event, exists := r.events[id]
-	if !exists {
-		return nil, domain.NewNotFoundError("событие не найдено")
-	}
+	return len(r.store.events)
+}
 
-	return event, nil
+// GetByID возвращает событие по ID
+func (r *MemoryEventRepository) GetByID(ctx context.Context, id int) (*domain.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.store.GetByID(ctx, id)
 }
 
 // GetByUserAndDate возвращает события пользователя на конкретную дату
-func (r *MemoryEventRepository) GetByUserAndDate(userID int, date time.Time) ([]*domain.Event, error) {
+func (r *MemoryEventRepository) GetByUserAndDate(ctx context.Context, userID int, date time.Time) ([]*domain.Event, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	var events []*domain.Event
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
+	return r.store.GetByUserAndDate(ctx, userID, date)
+}
 
-	for _, eventID := range r.users[userID] {
-		if event, exists := r.events[eventID]; exists {
-			if event.Date.After(startOfDay) && event.Date.Before(endOfDay) {
-				events = append(events, event)
-			}
-		}
-	}
+// GetByUserAndDateRange возвращает события пользователя в указанном диапазоне дат
+func (r *MemoryEventRepository) GetByUserAndDateRange(ctx context.Context, userID int, startDate, endDate time.Time) ([]*domain.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-	return events, nil
+	return r.store.GetByUserAndDateRange(ctx, userID, startDate, endDate)
 }
 
-// GetByUserAndDateRange возвращает события пользователя в указанном диапазоне дат
-func (r *MemoryEventRepository) GetByUserAndDateRange(userID int, startDate, endDate time.Time) ([]*domain.Event, error) {
+// GetOverlapping возвращает события пользователя, пересекающиеся с периодом [start, end)
+func (r *MemoryEventRepository) GetOverlapping(ctx context.Context, userID int, start, end time.Time) ([]*domain.Event, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	var events []*domain.Event
+	return r.store.GetOverlapping(ctx, userID, start, end)
+}
 
-	for _, eventID := range r.users[userID] {
-		if event, exists := r.events[eventID]; exists {
-			if (event.Date.After(startDate) || event.Date.Equal(startDate)) &&
-				(event.Date.Before(endDate) || event.Date.Equal(endDate)) {
-				events = append(events, event)
-			}
-		}
+// ListByUserAndDateRange возвращает страницу событий пользователя в указанном диапазоне дат
+func (r *MemoryEventRepository) ListByUserAndDateRange(ctx context.Context, userID int, startDate, endDate time.Time, page domain.PageRequest) (*domain.EventPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.store.ListByUserAndDateRange(ctx, userID, startDate, endDate, page)
+}
+
+// Search ищет события пользователя по тексту с ранжированием по релевантности
+func (r *MemoryEventRepository) Search(ctx context.Context, userID int, query string, limit int) ([]*domain.SearchResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.store.Search(ctx, userID, query, limit)
+}
+
+// ReplaceTag переименовывает тег во всех событиях пользователя; пустое newName снимает тег
+func (r *MemoryEventRepository) ReplaceTag(ctx context.Context, userID int, oldName, newName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.store.ReplaceTag(ctx, userID, oldName, newName)
+}
+
+// WithTx выполняет fn в транзакции. Транзакция удерживает блокировку репозитория, поэтому
+// чтения и изменения внутри fn не перемежаются с другими запросами. Если fn возвращает ошибку
+// или паникует, все изменения транзакции откатываются. Методы транзакции проверяют отмену ctx,
+// поэтому отмененный запрос прерывает транзакцию с откатом.
+func (r *MemoryEventRepository) WithTx(ctx context.Context, fn func(tx domain.EventRepository) error) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Пока транзакция ждала блокировку, запрос мог быть отменен
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
-	return events, nil
+	tx := &memoryTx{eventStore: r.store}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.rollback()
+			panic(p)
+		}
+		if err != nil {
+			tx.rollback()
+		}
+	}()
+
+	return fn(tx)
 }
@@ -2,10 +2,12 @@ package repository
 
 import (
 	"calendar/internal/domain"
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMemoryEventRepository_Create(t *testing.T) {
@@ -16,7 +18,7 @@ func TestMemoryEventRepository_Create(t *testing.T) {
 		Text:   "Тестовое событие",
 	}
 
-	err := repo.Create(event)
+	err := repo.Create(context.Background(), event)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, event.ID)
 	// CreatedAt и UpdatedAt устанавливаются в сервисе, а не в репозитории
@@ -31,20 +33,21 @@ func TestMemoryEventRepository_GetByID(t *testing.T) {
 	}
 
 	// Создаем событие
-	err := repo.Create(event)
+	err := repo.Create(context.Background(), event)
 	assert.NoError(t, err)
 
 	// Получаем событие по ID
-	retrievedEvent, err := repo.GetByID(event.ID)
+	retrievedEvent, err := repo.GetByID(context.Background(), event.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, event, retrievedEvent)
 
 	// Пытаемся получить несуществующее событие
-	_, err = repo.GetByID(999)
+	_, err = repo.GetByID(context.Background(), 999)
 	assert.Error(t, err)
 	appErr, ok := err.(*domain.AppError)
 	assert.True(t, ok)
 	assert.Equal(t, domain.StatusNotFound, appErr.GetStatusCode())
+	assert.Equal(t, domain.CodeEventNotFound, appErr.ErrorCode())
 }
 
 func TestMemoryEventRepository_Update(t *testing.T) {
@@ -56,22 +59,22 @@ func TestMemoryEventRepository_Update(t *testing.T) {
 	}
 
 	// Создаем событие
-	err := repo.Create(event)
+	err := repo.Create(context.Background(), event)
 	assert.NoError(t, err)
 
 	// Обновляем событие
 	event.Text = "Обновленное событие"
-	err = repo.Update(event)
+	err = repo.Update(context.Background(), event)
 	assert.NoError(t, err)
 
 	// Проверяем, что событие обновлено
-	retrievedEvent, err := repo.GetByID(event.ID)
+	retrievedEvent, err := repo.GetByID(context.Background(), event.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, "Обновленное событие", retrievedEvent.Text)
 
 	// Пытаемся обновить несуществующее событие
 	nonExistentEvent := &domain.Event{ID: 999, UserID: 1, Date: time.Now(), Text: "Несуществующее"}
-	err = repo.Update(nonExistentEvent)
+	err = repo.Update(context.Background(), nonExistentEvent)
 	assert.Error(t, err)
 	appErr, ok := err.(*domain.AppError)
 	assert.True(t, ok)
@@ -87,15 +90,15 @@ func TestMemoryEventRepository_Delete(t *testing.T) {
 	}
 
 	// Создаем событие
-	err := repo.Create(event)
+	err := repo.Create(context.Background(), event)
 	assert.NoError(t, err)
 
 	// Удаляем событие
-	err = repo.Delete(event.ID, event.UserID)
+	err = repo.Delete(context.Background(), event.ID, event.UserID)
 	assert.NoError(t, err)
 
 	// Проверяем, что событие удалено
-	_, err = repo.GetByID(event.ID)
+	_, err = repo.GetByID(context.Background(), event.ID)
 	assert.Error(t, err)
 	appErr, ok := err.(*domain.AppError)
 	assert.True(t, ok)
@@ -111,11 +114,11 @@ func TestMemoryEventRepository_Delete_AccessDenied(t *testing.T) {
 	}
 
 	// Создаем событие
-	err := repo.Create(event)
+	err := repo.Create(context.Background(), event)
 	assert.NoError(t, err)
 
 	// Пытаемся удалить событие от имени другого пользователя
-	err = repo.Delete(event.ID, 2)
+	err = repo.Delete(context.Background(), event.ID, 2)
 	assert.Error(t, err)
 	appErr, ok := err.(*domain.AppError)
 	assert.True(t, ok)
@@ -142,12 +145,12 @@ func TestMemoryEventRepository_GetByUserAndDate(t *testing.T) {
 		Text:   "Событие 3",
 	}
 
-	repo.Create(event1)
-	repo.Create(event2)
-	repo.Create(event3)
+	repo.Create(context.Background(), event1)
+	repo.Create(context.Background(), event2)
+	repo.Create(context.Background(), event3)
 
 	// Получаем события на 31 декабря
-	events, err := repo.GetByUserAndDate(1, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))
+	events, err := repo.GetByUserAndDate(context.Background(), 1, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))
 	assert.NoError(t, err)
 	assert.Len(t, events, 2)
 }
@@ -172,19 +175,47 @@ func TestMemoryEventRepository_GetByUserAndDateRange(t *testing.T) {
 		Text:   "Событие 3",
 	}
 
-	repo.Create(event1)
-	repo.Create(event2)
-	repo.Create(event3)
+	repo.Create(context.Background(), event1)
+	repo.Create(context.Background(), event2)
+	repo.Create(context.Background(), event3)
 
 	// Получаем события за период с 30 декабря по 1 января
 	startDate := time.Date(2023, 12, 30, 0, 0, 0, 0, time.UTC)
 	endDate := time.Date(2024, 1, 1, 23, 59, 59, 0, time.UTC)
 
-	events, err := repo.GetByUserAndDateRange(1, startDate, endDate)
+	events, err := repo.GetByUserAndDateRange(context.Background(), 1, startDate, endDate)
 	assert.NoError(t, err)
 	assert.Len(t, events, 3)
 }
 
+func TestMemoryEventRepository_GetOverlapping(t *testing.T) {
+	repo := NewMemoryEventRepository()
+	ctx := context.Background()
+	from := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC)
+
+	events := []*domain.Event{
+		// Командировка началась за три дня до периода и еще продолжается
+		{UserID: 1, Date: from.Add(-72 * time.Hour), EndDate: from.Add(2 * time.Hour), Text: "Командировка"},
+		{UserID: 1, Date: from.Add(3 * time.Hour), Text: "Встреча"},
+		// Закончилось ровно в начале периода - не пересекается
+		{UserID: 1, Date: from.Add(-2 * time.Hour), EndDate: from, Text: "Завтрак"},
+		// Начинается ровно в конце периода - не пересекается
+		{UserID: 1, Date: to, Text: "Ужин"},
+		{UserID: 2, Date: from.Add(time.Hour), Text: "Чужое событие"},
+	}
+	for _, event := range events {
+		assert.NoError(t, repo.Create(ctx, event))
+	}
+
+	overlapping, err := repo.GetOverlapping(ctx, 1, from, to)
+	assert.NoError(t, err)
+	if assert.Len(t, overlapping, 2) {
+		assert.Equal(t, "Командировка", overlapping[0].Text)
+		assert.Equal(t, "Встреча", overlapping[1].Text)
+	}
+}
+
 func TestMemoryEventRepository_ConcurrentAccess(t *testing.T) {
 	repo := NewMemoryEventRepository()
 
@@ -199,10 +230,10 @@ func TestMemoryEventRepository_ConcurrentAccess(t *testing.T) {
 				Text:   "Событие",
 			}
 
-			repo.Create(event)
-			repo.GetByID(event.ID)
-			repo.Update(event)
-			repo.Delete(event.ID, event.UserID)
+			repo.Create(context.Background(), event)
+			repo.GetByID(context.Background(), event.ID)
+			repo.Update(context.Background(), event)
+			repo.Delete(context.Background(), event.ID, event.UserID)
 
 			done <- true
 		}(i)
@@ -214,5 +245,389 @@ func TestMemoryEventRepository_ConcurrentAccess(t *testing.T) {
 	}
 
 	// Проверяем, что репозиторий не поврежден
-	assert.Equal(t, 11, repo.nextID) // 10 событий + 1 для следующего ID
+	assert.Equal(t, 11, repo.store.nextID) // 10 событий + 1 для следующего ID
+}
+
+func TestMemoryEventRepository_GetByUserAndDate_TimeZone(t *testing.T) {
+	repo := NewMemoryEventRepository()
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	assert.NoError(t, err)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	// Полночь по Москве - это 21:00 предыдущего дня по UTC
+	repo.Create(context.Background(), &domain.Event{UserID: 1, Date: time.Date(2025, 12, 18, 0, 0, 0, 0, moscow), Text: "Полночь"})
+	repo.Create(context.Background(), &domain.Event{UserID: 1, Date: time.Date(2025, 12, 18, 23, 30, 0, 0, moscow), Text: "Поздно вечером"})
+	repo.Create(context.Background(), &domain.Event{UserID: 1, Date: time.Date(2025, 12, 19, 0, 0, 0, 0, moscow), Text: "Следующий день"})
+
+	events, err := repo.GetByUserAndDate(context.Background(), 1, time.Date(2025, 12, 18, 0, 0, 0, 0, moscow))
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	// По UTC тот же день сдвинут на три часа назад
+	events, err = repo.GetByUserAndDate(context.Background(), 1, time.Date(2025, 12, 18, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "Поздно вечером", events[0].Text)
+	assert.Equal(t, "Следующий день", events[1].Text)
+
+	// 30 марта 2025 года в Берлине длится 23 часа
+	repo.Create(context.Background(), &domain.Event{UserID: 2, Date: time.Date(2025, 3, 30, 23, 30, 0, 0, berlin), Text: "Конец дня перехода"})
+	repo.Create(context.Background(), &domain.Event{UserID: 2, Date: time.Date(2025, 3, 31, 0, 30, 0, 0, berlin), Text: "Начало следующего дня"})
+
+	events, err = repo.GetByUserAndDate(context.Background(), 2, time.Date(2025, 3, 30, 0, 0, 0, 0, berlin))
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "Конец дня перехода", events[0].Text)
+}
+
+func TestMemoryEventRepository_ListByUserAndDateRange(t *testing.T) {
+	repo := NewMemoryEventRepository()
+
+	day := time.Date(2025, 12, 18, 0, 0, 0, 0, time.UTC)
+	// Создаем события не по порядку дат; у двух событий одинаковая дата
+	repo.Create(context.Background(), &domain.Event{UserID: 1, Date: day.Add(3 * time.Hour), UpdatedAt: day.Add(1 * time.Hour), Text: "1"})
+	repo.Create(context.Background(), &domain.Event{UserID: 1, Date: day.Add(1 * time.Hour), UpdatedAt: day.Add(3 * time.Hour), Text: "2"})
+	repo.Create(context.Background(), &domain.Event{UserID: 1, Date: day.Add(2 * time.Hour), UpdatedAt: day.Add(2 * time.Hour), Text: "3"})
+	repo.Create(context.Background(), &domain.Event{UserID: 1, Date: day.Add(1 * time.Hour), UpdatedAt: day.Add(4 * time.Hour), Text: "4"})
+	repo.Create(context.Background(), &domain.Event{UserID: 2, Date: day.Add(1 * time.Hour), Text: "Чужое"})
+
+	start, end := day, day.AddDate(0, 0, 1)
+
+	ids := func(events []*domain.Event) []int {
+		var result []int
+		for _, event := range events {
+			result = append(result, event.ID)
+		}
+		return result
+	}
+
+	// Постраничный обход по дате, затем по ID
+	var collected []int
+	page := domain.PageRequest{Sort: domain.SortByDate, Limit: 3}
+	for {
+		result, err := repo.ListByUserAndDateRange(context.Background(), 1, start, end, page)
+		assert.NoError(t, err)
+		collected = append(collected, ids(result.Events)...)
+		if result.NextCursor == "" {
+			break
+		}
+		page.Cursor = result.NextCursor
+	}
+	assert.Equal(t, []int{2, 4, 3, 1}, collected)
+
+	// Сортировка по времени изменения
+	result, err := repo.ListByUserAndDateRange(context.Background(), 1, start, end, domain.PageRequest{Sort: domain.SortByUpdatedAt})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3, 2, 4}, ids(result.Events))
+	assert.Empty(t, result.NextCursor)
+
+	// Курсор другого порядка сортировки отклоняется
+	first, err := repo.ListByUserAndDateRange(context.Background(), 1, start, end, domain.PageRequest{Sort: domain.SortByDate, Limit: 1})
+	assert.NoError(t, err)
+	_, err = repo.ListByUserAndDateRange(context.Background(), 1, start, end, domain.PageRequest{Sort: domain.SortByUpdatedAt, Cursor: first.NextCursor})
+	assert.Error(t, err)
+	appErr, ok := err.(*domain.AppError)
+	assert.True(t, ok)
+	assert.Equal(t, domain.StatusBadRequest, appErr.GetStatusCode())
+
+	_, err = repo.ListByUserAndDateRange(context.Background(), 1, start, end, domain.PageRequest{Sort: domain.SortByDate, Cursor: "!!!"})
+	assert.Error(t, err)
+}
+
+func TestMemoryEventRepository_Search(t *testing.T) {
+	repo := NewMemoryEventRepository()
+	date := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
+
+	dentist := &domain.Event{UserID: 1, Date: date, Text: "Запись к стоматологу"}
+	repo.Create(context.Background(), dentist)
+	repo.Create(context.Background(), &domain.Event{UserID: 1, Date: date, Text: "Стоматолог: стоматолог повторно, ЁЛКА"})
+	repo.Create(context.Background(), &domain.Event{UserID: 1, Date: date, Text: "Dentist appointment"})
+	repo.Create(context.Background(), &domain.Event{UserID: 2, Date: date, Text: "Стоматолог другого пользователя"})
+
+	ids := func(results []*domain.SearchResult) []int {
+		var result []int
+		for _, r := range results {
+			result = append(result, r.Event.ID)
+		}
+		return result
+	}
+
+	// Ранжирование и разбиение на термы проверяются в пакете search; здесь - события пользователя
+	results, err := repo.Search(context.Background(), 1, "стоматолог", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, ids(results))
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "Стоматолог: стоматолог повторно, ЁЛКА", results[0].Event.Text)
+		assert.Greater(t, results[0].Score, results[1].Score)
+	}
+
+	// Индекс обновляется при изменении и удалении
+	updated := *dentist
+	updated.Text = "Запись к терапевту"
+	assert.NoError(t, repo.Update(context.Background(), &updated))
+
+	results, err = repo.Search(context.Background(), 1, "стоматолог", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, ids(results))
+
+	results, err = repo.Search(context.Background(), 1, "терап", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, ids(results))
+
+	assert.NoError(t, repo.Delete(context.Background(), 1, 1))
+	results, err = repo.Search(context.Background(), 1, "терапевту", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+// textFilter - фильтр событий по точному тексту
+type textFilter string
+
+func (f textFilter) Match(event *domain.Event) bool {
+	return event.Text == string(f)
+}
+
+func TestMemoryEventRepository_ListByUserAndDateRange_Filter(t *testing.T) {
+	repo := NewMemoryEventRepository()
+
+	day := time.Date(2025, 12, 18, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		text := "Другое"
+		if i%2 == 0 {
+			text = "Нужное"
+		}
+		repo.Create(context.Background(), &domain.Event{UserID: 1, Date: day.Add(time.Duration(i) * time.Hour), Text: text})
+	}
+
+	// Фильтр применяется до разбиения на страницы, поэтому страница заполнена целиком
+	page := domain.PageRequest{Sort: domain.SortByDate, Limit: 2, Filter: textFilter("Нужное")}
+	result, err := repo.ListByUserAndDateRange(context.Background(), 1, day, day.AddDate(0, 0, 1), page)
+	assert.NoError(t, err)
+	assert.Len(t, result.Events, 2)
+	assert.Equal(t, 1, result.Events[0].ID)
+	assert.Equal(t, 3, result.Events[1].ID)
+
+	page.Cursor = result.NextCursor
+	result, err = repo.ListByUserAndDateRange(context.Background(), 1, day, day.AddDate(0, 0, 1), page)
+	assert.NoError(t, err)
+	assert.Len(t, result.Events, 1)
+	assert.Equal(t, 5, result.Events[0].ID)
+	assert.Empty(t, result.NextCursor)
+}
+
+func TestMemoryEventRepository_ReplaceTag(t *testing.T) {
+	repo := NewMemoryEventRepository()
+	date := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
+
+	tagged := &domain.Event{UserID: 1, Date: date, Text: "Планерка", Tags: []string{"Работа", "Важное"}}
+	untagged := &domain.Event{UserID: 1, Date: date, Text: "Обед"}
+	other := &domain.Event{UserID: 2, Date: date, Text: "Чужое", Tags: []string{"Работа"}}
+	for _, event := range []*domain.Event{tagged, untagged, other} {
+		assert.NoError(t, repo.Create(context.Background(), event))
+	}
+
+	// Переименование затрагивает только события пользователя
+	assert.NoError(t, repo.ReplaceTag(context.Background(), 1, "работа", "Офис"))
+
+	renamed, err := repo.GetByID(context.Background(), tagged.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Офис", "Важное"}, renamed.Tags)
+
+	foreign, err := repo.GetByID(context.Background(), other.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Работа"}, foreign.Tags)
+
+	// Пустое новое название снимает тег
+	assert.NoError(t, repo.ReplaceTag(context.Background(), 1, "Офис", ""))
+
+	removed, err := repo.GetByID(context.Background(), tagged.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Важное"}, removed.Tags)
+
+	plain, err := repo.GetByID(context.Background(), untagged.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, plain.Tags)
+}
+
+func TestMemoryEventRepository_Search_Details(t *testing.T) {
+	repo := NewMemoryEventRepository()
+	date := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
+
+	event := &domain.Event{
+		UserID:      1,
+		Date:        date,
+		Text:        "Запись",
+		Title:       "Стоматолог",
+		Description: "Взять снимки",
+		Location:    &domain.Location{Text: "Клиника на Арбате"},
+	}
+	repo.Create(context.Background(), event)
+
+	// Поиск учитывает заголовок, описание и место проведения
+	for _, query := range []string{"стоматолог", "снимки", "арбат"} {
+		results, err := repo.Search(context.Background(), 1, query, 10)
+		assert.NoError(t, err)
+		if assert.Len(t, results, 1, query) {
+			assert.Equal(t, event.ID, results[0].Event.ID)
+		}
+	}
+}
+
+func TestMemoryEventRepository_WithTx(t *testing.T) {
+	repo := NewMemoryEventRepository()
+	date := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
+
+	existing := &domain.Event{UserID: 1, Date: date, Text: "Существующее", Tags: []string{"Работа"}}
+	assert.NoError(t, repo.Create(context.Background(), existing))
+
+	// Ошибка внутри транзакции откатывает все ее изменения
+	failure := domain.NewValidationError("откат")
+	err := repo.WithTx(context.Background(), func(tx domain.EventRepository) error {
+		assert.NoError(t, tx.Create(context.Background(), &domain.Event{UserID: 1, Date: date, Text: "Новое"}))
+		assert.NoError(t, tx.Update(context.Background(), &domain.Event{ID: existing.ID, UserID: 1, Date: date, Text: "Измененное", Tags: existing.Tags}))
+		assert.NoError(t, tx.ReplaceTag(context.Background(), 1, "Работа", "Офис"))
+
+		// Внутри транзакции изменения видны
+		events, err := tx.GetByUserAndDate(context.Background(), 1, date)
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+
+		assert.NoError(t, tx.Delete(context.Background(), existing.ID, 1))
+		return failure
+	})
+	assert.Equal(t, failure, err)
+
+	events, _ := repo.GetByUserAndDate(context.Background(), 1, date)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "Существующее", events[0].Text)
+		assert.Equal(t, []string{"Работа"}, events[0].Tags)
+	}
+	results, _ := repo.Search(context.Background(), 1, "новое", 10)
+	assert.Empty(t, results)
+
+	// Паника внутри транзакции тоже откатывает изменения
+	assert.Panics(t, func() {
+		repo.WithTx(context.Background(), func(tx domain.EventRepository) error {
+			tx.Delete(context.Background(), existing.ID, 1)
+			panic("сбой")
+		})
+	})
+	_, err = repo.GetByID(context.Background(), existing.ID)
+	assert.NoError(t, err)
+
+	// Успешная транзакция фиксирует изменения
+	created := &domain.Event{UserID: 1, Date: date, Text: "Новое"}
+	err = repo.WithTx(context.Background(), func(tx domain.EventRepository) error {
+		if err := tx.Create(context.Background(), created); err != nil {
+			return err
+		}
+		return tx.Delete(context.Background(), existing.ID, 1)
+	})
+	assert.NoError(t, err)
+
+	events, _ = repo.GetByUserAndDate(context.Background(), 1, date)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, created.ID, events[0].ID)
+	}
+}
+
+func TestMemoryEventRepository_Canceled(t *testing.T) {
+	repo := NewMemoryEventRepository()
+	date := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
+	existing := &domain.Event{UserID: 1, Date: date, Text: "Существующее"}
+	assert.NoError(t, repo.Create(context.Background(), existing))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := repo.ListByUserAndDateRange(ctx, 1, date.AddDate(0, 0, -1), date.AddDate(0, 0, 1), domain.PageRequest{})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	err = repo.Create(ctx, &domain.Event{UserID: 1, Date: date, Text: "Новое"})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Отмена посреди транзакции откатывает уже сделанные изменения
+	ctx, cancel = context.WithCancel(context.Background())
+	err = repo.WithTx(ctx, func(tx domain.EventRepository) error {
+		if err := tx.Delete(ctx, existing.ID, 1); err != nil {
+			return err
+		}
+		cancel()
+		return tx.Create(ctx, &domain.Event{UserID: 1, Date: date, Text: "Новое"})
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	events, err := repo.GetByUserAndDate(context.Background(), 1, date)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, existing.ID, events[0].ID)
+	}
+}
+
+func TestMemoryEventRepository_Ping(t *testing.T) {
+	repo := NewMemoryEventRepository()
+	assert.NoError(t, repo.Ping(context.Background()))
+
+	// Долгая транзакция удерживает блокировку
+	locked := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		repo.WithTx(context.Background(), func(tx domain.EventRepository) error {
+			close(locked)
+			<-release
+			return nil
+		})
+	}()
+	<-locked
+
+	// Ping не ждет блокировку дольше, чем позволяет контекст
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	assert.ErrorIs(t, repo.Ping(ctx), context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), time.Second)
+
+	close(release)
+	<-done
+	assert.NoError(t, repo.Ping(context.Background()))
+}
+
+func TestMemoryEventRepository_CursorExtremeDates(t *testing.T) {
+	repo := NewMemoryEventRepository()
+
+	// Годы за пределами диапазона UnixNano
+	first := time.Date(1, 1, 1, 10, 0, 0, 0, time.UTC)
+	last := time.Date(9999, 12, 31, 10, 0, 0, 123456789, time.UTC)
+	repo.Create(context.Background(), &domain.Event{UserID: 1, Date: first, Text: "Первый"})
+	repo.Create(context.Background(), &domain.Event{UserID: 1, Date: last, Text: "Последний"})
+	repo.Create(context.Background(), &domain.Event{UserID: 1, Date: last.Add(time.Nanosecond), Text: "Самый последний"})
+
+	start, end := first, last.Add(time.Hour)
+
+	var collected []time.Time
+	page := domain.PageRequest{Sort: domain.SortByDate, Limit: 1}
+	for {
+		result, err := repo.ListByUserAndDateRange(context.Background(), 1, start, end, page)
+		require.NoError(t, err)
+		for _, event := range result.Events {
+			collected = append(collected, event.Date)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+
+		decoded, err := decodeCursor(result.NextCursor, domain.SortByDate)
+		require.NoError(t, err)
+		assert.True(t, decoded.key.Equal(result.Events[len(result.Events)-1].Date))
+		page.Cursor = result.NextCursor
+	}
+
+	require.Len(t, collected, 3)
+	assert.True(t, collected[0].Equal(first))
+	assert.True(t, collected[1].Equal(last))
+	assert.True(t, collected[2].Equal(last.Add(time.Nanosecond)))
 }
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// idempotencyKey - ключ идемпотентности в пространстве пользователя
+type idempotencyKey struct {
+	userID int
+	key    string
+}
+
+// MemoryIdempotencyStore реализует in-memory хранилище ключей идемпотентности.
+// Запись хранится ttl с момента резервирования или сохранения ответа.
+type MemoryIdempotencyStore struct {
+	records        map[idempotencyKey]*domain.IdempotencyRecord
+	ttl            time.Duration
+	now            func() time.Time
+	mu             sync.Mutex
+	cleanupRunning atomic.Bool
+}
+
+// NewMemoryIdempotencyStore создает новое in-memory хранилище ключей идемпотентности
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		records: make(map[idempotencyKey]*domain.IdempotencyRecord),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// Reserve резервирует ключ за запросом или возвращает уже существующую запись
+func (s *MemoryIdempotencyStore) Reserve(userID int, key, fingerprint string) (*domain.IdempotencyRecord, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := idempotencyKey{userID: userID, key: key}
+	now := s.now()
+	if record, exists := s.records[id]; exists && now.Before(record.ExpiresAt) {
+		copied := *record
+		return &copied, false
+	}
+
+	s.records[id] = &domain.IdempotencyRecord{
+		Fingerprint: fingerprint,
+		ExpiresAt:   now.Add(s.ttl),
+	}
+	return nil, true
+}
+
+// Complete сохраняет ответ на запрос с зарезервированным ключом
+func (s *MemoryIdempotencyStore) Complete(userID int, key string, response *domain.IdempotentResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, exists := s.records[idempotencyKey{userID: userID, key: key}]
+	if !exists {
+		return
+	}
+
+	record.Response = response
+	record.ExpiresAt = s.now().Add(s.ttl)
+}
+
+// Release удаляет резервирование ключа
+func (s *MemoryIdempotencyStore) Release(userID int, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, idempotencyKey{userID: userID, key: key})
+}
+
+// Cleanup удаляет записи с истекшим сроком хранения
+func (s *MemoryIdempotencyStore) Cleanup() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for id, record := range s.records {
+		if !now.Before(record.ExpiresAt) {
+			delete(s.records, id)
+		}
+	}
+}
+
+// StartCleanup запускает периодическую очистку истекших записей и возвращает функцию ее остановки
+func (s *MemoryIdempotencyStore) StartCleanup(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	s.cleanupRunning.Store(true)
+	go func() {
+		defer s.cleanupRunning.Store(false)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.Cleanup()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// CleanupRunning сообщает, запущена ли периодическая очистка истекших записей
+func (s *MemoryIdempotencyStore) CleanupRunning() bool {
+	return s.cleanupRunning.Load()
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	store := NewMemoryIdempotencyStore(time.Hour)
+	now := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	// Первый запрос резервирует ключ
+	record, reserved := store.Reserve(1, "key", "fp")
+	assert.True(t, reserved)
+	assert.Nil(t, record)
+
+	// Повтор до завершения первого запроса видит незавершенную запись
+	record, reserved = store.Reserve(1, "key", "fp")
+	assert.False(t, reserved)
+	assert.Nil(t, record.Response)
+
+	// Ключи разных пользователей независимы
+	_, reserved = store.Reserve(2, "key", "fp")
+	assert.True(t, reserved)
+
+	response := &domain.IdempotentResponse{StatusCode: 200, Body: []byte(`{"result":1}`)}
+	store.Complete(1, "key", response)
+
+	record, reserved = store.Reserve(1, "key", "other")
+	assert.False(t, reserved)
+	assert.Equal(t, "fp", record.Fingerprint)
+	assert.Equal(t, response, record.Response)
+
+	// После истечения срока ключ можно использовать заново
+	now = now.Add(time.Hour)
+	store.Cleanup()
+	assert.Empty(t, store.records)
+
+	_, reserved = store.Reserve(1, "key", "other")
+	assert.True(t, reserved)
+
+	// Снятое резервирование освобождает ключ
+	store.Release(1, "key")
+	_, reserved = store.Reserve(1, "key", "fp")
+	assert.True(t, reserved)
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryTagRepository реализует in-memory репозиторий для тегов
+type MemoryTagRepository struct {
+	tags   map[int]*domain.Tag
+	mu     sync.RWMutex
+	nextID int
+}
+
+// NewMemoryTagRepository создает новый экземпляр in-memory репозитория тегов
+func NewMemoryTagRepository() *MemoryTagRepository {
+	return &MemoryTagRepository{
+		tags:   make(map[int]*domain.Tag),
+		nextID: 1,
+	}
+}
+
+// Create создает новый тег; название должно быть уникальным у пользователя без учета регистра
+func (r *MemoryTagRepository) Create(tag *domain.Tag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkUniqueName(tag); err != nil {
+		return err
+	}
+
+	tag.ID = r.nextID
+	r.tags[tag.ID] = tag
+	r.nextID++
+
+	return nil
+}
+
+// Update обновляет существующий тег
+func (r *MemoryTagRepository) Update(tag *domain.Tag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tags[tag.ID]; !exists {
+		return domain.NewNotFoundError("тег не найден").WithCode(domain.CodeTagNotFound)
+	}
+
+	if err := r.checkUniqueName(tag); err != nil {
+		return err
+	}
+
+	r.tags[tag.ID] = tag
+	return nil
+}
+
+// Delete удаляет тег
+func (r *MemoryTagRepository) Delete(id int, userID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tag, exists := r.tags[id]
+	if !exists {
+		return domain.NewNotFoundError("тег не найден").WithCode(domain.CodeTagNotFound)
+	}
+
+	if tag.UserID != userID {
+		return domain.NewAccessDeniedError("нет прав для удаления этого тега")
+	}
+
+	delete(r.tags, id)
+	return nil
+}
+
+// GetByID возвращает тег по ID
+func (r *MemoryTagRepository) GetByID(id int) (*domain.Tag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tag, exists := r.tags[id]
+	if !exists {
+		return nil, domain.NewNotFoundError("тег не найден").WithCode(domain.CodeTagNotFound)
+	}
+
+	return tag, nil
+}
+
+// GetByUser возвращает теги пользователя, упорядоченные по ID
+func (r *MemoryTagRepository) GetByUser(userID int) ([]*domain.Tag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var tags []*domain.Tag
+	for _, tag := range r.tags {
+		if tag.UserID == userID {
+			tags = append(tags, tag)
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].ID < tags[j].ID
+	})
+
+	return tags, nil
+}
+
+// checkUniqueName проверяет, что у пользователя нет другого тега с таким названием.
+// Вызывается под блокировкой записи, поэтому параллельные запросы не создают дубликатов
+func (r *MemoryTagRepository) checkUniqueName(tag *domain.Tag) error {
+	for _, existing := range r.tags {
+		if existing.ID != tag.ID && existing.UserID == tag.UserID && strings.EqualFold(existing.Name, tag.Name) {
+			return domain.NewFieldError("name", domain.CodeTagAlreadyExists, "тег с названием "+tag.Name+" уже существует").
+				WithDetails(domain.MsgTagAlreadyExists, domain.MessageParams{"name": tag.Name})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryTagRepository_UniqueName(t *testing.T) {
+	repo := NewMemoryTagRepository()
+	require.NoError(t, repo.Create(&domain.Tag{UserID: 1, Name: "Работа"}))
+	require.NoError(t, repo.Create(&domain.Tag{UserID: 1, Name: "Здоровье"}))
+
+	tests := []struct {
+		name        string
+		create      *domain.Tag
+		update      *domain.Tag
+		expectError bool
+	}{
+		{name: "Название занято без учета регистра", create: &domain.Tag{UserID: 1, Name: "РАБОТА"}, expectError: true},
+		{name: "Такое же название у другого пользователя", create: &domain.Tag{UserID: 2, Name: "Работа"}},
+		{name: "Переименование в занятое название", update: &domain.Tag{ID: 2, UserID: 1, Name: "работа"}, expectError: true},
+		{name: "Смена регистра собственного названия", update: &domain.Tag{ID: 1, UserID: 1, Name: "работа"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.create != nil {
+				err = repo.Create(tt.create)
+			} else {
+				err = repo.Update(tt.update)
+			}
+
+			if !tt.expectError {
+				assert.NoError(t, err)
+				return
+			}
+
+			appErr, ok := err.(*domain.AppError)
+			require.True(t, ok)
+			assert.Equal(t, domain.CodeTagAlreadyExists, appErr.ErrorCode())
+		})
+	}
+}
+
+func TestMemoryTagRepository_ConcurrentCreate(t *testing.T) {
+	repo := NewMemoryTagRepository()
+
+	const workers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Названия отличаются только регистром
+			name := "работа"
+			if i%2 == 0 {
+				name = strings.ToUpper(name)
+			}
+			errs <- repo.Create(&domain.Tag{UserID: 1, Name: name, Color: fmt.Sprintf("#%06d", i)})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	var created int
+	for err := range errs {
+		if err == nil {
+			created++
+		}
+	}
+	assert.Equal(t, 1, created)
+
+	tags, err := repo.GetByUser(1)
+	require.NoError(t, err)
+	assert.Len(t, tags, 1)
+}
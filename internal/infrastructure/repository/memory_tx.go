@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"context"
+)
+
+// memoryTx - транзакция in-memory репозитория событий. Изменения применяются к хранилищу сразу,
+// а для каждого записывается обратное действие, чтобы при откате вернуть прежнее состояние.
+// Чтения выполняются встроенным eventStore и видят изменения транзакции.
+type memoryTx struct {
+	*eventStore
+	undo []func()
+}
+
+// Create создает новое событие в транзакции
+func (tx *memoryTx) Create(ctx context.Context, event *domain.Event) error {
+	if err := tx.eventStore.Create(ctx, event); err != nil {
+		return err
+	}
+
+	tx.undo = append(tx.undo, func() { tx.remove(event) })
+	return nil
+}
+
+// Update обновляет событие в транзакции
+func (tx *memoryTx) Update(ctx context.Context, event *domain.Event) error {
+	previous, exists := tx.events[event.ID]
+	if err := tx.eventStore.Update(ctx, event); err != nil {
+		return err
+	}
+
+	if exists {
+		tx.undo = append(tx.undo, func() { tx.set(previous) })
+	}
+	return nil
+}
+
+// Delete удаляет событие в транзакции
+func (tx *memoryTx) Delete(ctx context.Context, id int, userID int) error {
+	previous := tx.events[id]
+	if err := tx.eventStore.Delete(ctx, id, userID); err != nil {
+		return err
+	}
+
+	tx.undo = append(tx.undo, func() { tx.put(previous) })
+	return nil
+}
+
+// ReplaceTag заменяет тег в событиях пользователя в транзакции
+func (tx *memoryTx) ReplaceTag(ctx context.Context, userID int, oldName, newName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	previous := tx.replaceTag(userID, oldName, newName)
+
+	tx.undo = append(tx.undo, func() {
+		for _, event := range previous {
+			tx.set(event)
+		}
+	})
+	return nil
+}
+
+// WithTx внутри транзакции выполняет fn в той же транзакции
+func (tx *memoryTx) WithTx(ctx context.Context, fn func(tx domain.EventRepository) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return fn(tx)
+}
+
+// rollback отменяет изменения транзакции в обратном порядке
+func (tx *memoryTx) rollback() {
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		tx.undo[i]()
+	}
+	tx.undo = nil
+}
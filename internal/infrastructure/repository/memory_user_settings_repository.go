@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"sync"
+)
+
+// MemoryUserSettingsRepository реализует in-memory хранилище настроек пользователей
+type MemoryUserSettingsRepository struct {
+	settings map[int]domain.UserSettings
+	mu       sync.RWMutex
+}
+
+// NewMemoryUserSettingsRepository создает новый экземпляр in-memory хранилища настроек
+func NewMemoryUserSettingsRepository() *MemoryUserSettingsRepository {
+	return &MemoryUserSettingsRepository{
+		settings: make(map[int]domain.UserSettings),
+	}
+}
+
+// Get возвращает копию настроек пользователя
+func (r *MemoryUserSettingsRepository) Get(userID int) (*domain.UserSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings, exists := r.settings[userID]
+	if !exists {
+		return nil, domain.NewNotFoundError("настройки пользователя не найдены").WithCode(domain.CodeSettingsNotFound)
+	}
+
+	return &settings, nil
+}
+
+// Save сохраняет настройки пользователя
+func (r *MemoryUserSettingsRepository) Save(settings *domain.UserSettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.settings[settings.UserID] = *settings
+	return nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cursor - позиция последнего события страницы в выбранном порядке сортировки
+type cursor struct {
+	sort domain.EventSort
+	key  time.Time
+	id   int
+}
+
+// sortKey возвращает значение, по которому событие упорядочивается
+func sortKey(event *domain.Event, order domain.EventSort) time.Time {
+	if order == domain.SortByUpdatedAt {
+		return event.UpdatedAt
+	}
+	return event.Date
+}
+
+// sortEvents упорядочивает события по ключу сортировки, а при равенстве - по ID
+func sortEvents(events []*domain.Event, order domain.EventSort) {
+	sort.Slice(events, func(i, j int) bool {
+		ki, kj := sortKey(events[i], order), sortKey(events[j], order)
+		if !ki.Equal(kj) {
+			return ki.Before(kj)
+		}
+		return events[i].ID < events[j].ID
+	})
+}
+
+// encodeCursor кодирует позицию события в непрозрачную строку.
+// Время хранится секундами и наносекундами: UnixNano переполняется для дат вне 1678-2262 годов.
+func encodeCursor(event *domain.Event, order domain.EventSort) string {
+	key := sortKey(event, order)
+	raw := fmt.Sprintf("%s|%d|%d|%d", order, key.Unix(), key.Nanosecond(), event.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor разбирает строку курсора и проверяет, что он выдан для того же порядка сортировки
+func decodeCursor(value string, order domain.EventSort) (*cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, domain.NewFieldError("cursor", domain.CodeInvalidCursor, "некорректный cursor")
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 4 || domain.EventSort(parts[0]) != order {
+		return nil, domain.NewFieldError("cursor", domain.CodeInvalidCursor, "некорректный cursor")
+	}
+
+	var seconds, nanos int64
+	var id int
+	if _, err := fmt.Sscanf(strings.Join(parts[1:], " "), "%d %d %d", &seconds, &nanos, &id); err != nil || nanos < 0 || nanos >= int64(time.Second) {
+		return nil, domain.NewFieldError("cursor", domain.CodeInvalidCursor, "некорректный cursor")
+	}
+
+	return &cursor{sort: order, key: time.Unix(seconds, nanos), id: id}, nil
+}
+
+// after проверяет, находится ли событие строго после позиции курсора
+func (c *cursor) after(event *domain.Event) bool {
+	key := sortKey(event, c.sort)
+	if !key.Equal(c.key) {
+		return key.After(c.key)
+	}
+	return event.ID > c.id
+}
+
+// paginate упорядочивает события и вырезает страницу согласно запросу
+func paginate(events []*domain.Event, page domain.PageRequest) (*domain.EventPage, error) {
+	order := page.Sort
+	if order == "" {
+		order = domain.SortByDate
+	}
+
+	if page.Filter != nil {
+		matched := events[:0:0]
+		for _, event := range events {
+			if page.Filter.Match(event) {
+				matched = append(matched, event)
+			}
+		}
+		events = matched
+	}
+
+	sortEvents(events, order)
+
+	if page.Cursor != "" {
+		c, err := decodeCursor(page.Cursor, order)
+		if err != nil {
+			return nil, err
+		}
+		start := sort.Search(len(events), func(i int) bool {
+			return c.after(events[i])
+		})
+		events = events[start:]
+	}
+
+	result := &domain.EventPage{Events: events}
+	if page.Limit > 0 && len(events) > page.Limit {
+		result.Events = events[:page.Limit]
+		result.NextCursor = encodeCursor(result.Events[page.Limit-1], order)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName - имя трассировщика слоя хранения
+const tracerName = "calendar/internal/infrastructure/repository"
+
+// TracedEventRepository оборачивает репозиторий событий и открывает спан на каждый вызов.
+// Ошибки вызова записываются в спан.
+type TracedEventRepository struct {
+	repo    domain.EventRepository
+	backend string
+	tracer  trace.Tracer
+	txSpan  trace.Span // спан транзакции, к которому относятся вызовы внутри нее
+}
+
+// NewTracedEventRepository создает репозиторий с трассировкой поверх repo; backend - название хранилища в спанах.
+// Спаны создаются глобальным провайдером трассировки.
+func NewTracedEventRepository(repo domain.EventRepository, backend string) *TracedEventRepository {
+	return &TracedEventRepository{repo: repo, backend: backend, tracer: otel.Tracer(tracerName)}
+}
+
+// start открывает клиентский спан операции с хранилищем
+func (r *TracedEventRepository) start(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attribute.String("db.system.name", r.backend), attribute.String("db.operation.name", operation))
+	if r.txSpan != nil {
+		// Контекст вызова внутри транзакции приходит от вызывающего, поэтому родителем спана делаем транзакцию
+		ctx = trace.ContextWithSpan(ctx, r.txSpan)
+	}
+	return r.tracer.Start(ctx, "EventRepository."+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// endSpan записывает ошибку в спан и закрывает его
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Create создает новое событие
+func (r *TracedEventRepository) Create(ctx context.Context, event *domain.Event) error {
+	ctx, span := r.start(ctx, "Create", attribute.Int("user_id", event.UserID))
+	err := r.repo.Create(ctx, event)
+	endSpan(span, err)
+	return err
+}
+
+// Update обновляет существующее событие
+func (r *TracedEventRepository) Update(ctx context.Context, event *domain.Event) error {
+	ctx, span := r.start(ctx, "Update", attribute.Int("event_id", event.ID))
+	err := r.repo.Update(ctx, event)
+	endSpan(span, err)
+	return err
+}
+
+// Delete удаляет событие
+func (r *TracedEventRepository) Delete(ctx context.Context, id int, userID int) error {
+	ctx, span := r.start(ctx, "Delete", attribute.Int("event_id", id))
+	err := r.repo.Delete(ctx, id, userID)
+	endSpan(span, err)
+	return err
+}
+
+// GetByID возвращает событие по ID
+func (r *TracedEventRepository) GetByID(ctx context.Context, id int) (*domain.Event, error) {
+	ctx, span := r.start(ctx, "GetByID", attribute.Int("event_id", id))
+	event, err := r.repo.GetByID(ctx, id)
+	endSpan(span, err)
+	return event, err
+}
+
+// GetByUserAndDate возвращает события пользователя на конкретную дату
+func (r *TracedEventRepository) GetByUserAndDate(ctx context.Context, userID int, date time.Time) ([]*domain.Event, error) {
+	ctx, span := r.start(ctx, "GetByUserAndDate", attribute.Int("user_id", userID))
+	events, err := r.repo.GetByUserAndDate(ctx, userID, date)
+	span.SetAttributes(attribute.Int("events", len(events)))
+	endSpan(span, err)
+	return events, err
+}
+
+// GetByUserAndDateRange возвращает события пользователя в указанном диапазоне дат
+func (r *TracedEventRepository) GetByUserAndDateRange(ctx context.Context, userID int, startDate, endDate time.Time) ([]*domain.Event, error) {
+	ctx, span := r.start(ctx, "GetByUserAndDateRange", attribute.Int("user_id", userID))
+	events, err := r.repo.GetByUserAndDateRange(ctx, userID, startDate, endDate)
+	span.SetAttributes(attribute.Int("events", len(events)))
+	endSpan(span, err)
+	return events, err
+}
+
+// GetOverlapping возвращает события пользователя, пересекающиеся с периодом [start, end)
+func (r *TracedEventRepository) GetOverlapping(ctx context.Context, userID int, start, end time.Time) ([]*domain.Event, error) {
+	ctx, span := r.start(ctx, "GetOverlapping", attribute.Int("user_id", userID))
+	events, err := r.repo.GetOverlapping(ctx, userID, start, end)
+	span.SetAttributes(attribute.Int("events", len(events)))
+	endSpan(span, err)
+	return events, err
+}
+
+// ListByUserAndDateRange возвращает страницу событий пользователя в указанном диапазоне дат
+func (r *TracedEventRepository) ListByUserAndDateRange(ctx context.Context, userID int, startDate, endDate time.Time, page domain.PageRequest) (*domain.EventPage, error) {
+	ctx, span := r.start(ctx, "ListByUserAndDateRange", attribute.Int("user_id", userID), attribute.Int("limit", page.Limit))
+	result, err := r.repo.ListByUserAndDateRange(ctx, userID, startDate, endDate, page)
+	if result != nil {
+		span.SetAttributes(attribute.Int("events", len(result.Events)))
+	}
+	endSpan(span, err)
+	return result, err
+}
+
+// Search ищет события пользователя по тексту
+func (r *TracedEventRepository) Search(ctx context.Context, userID int, query string, limit int) ([]*domain.SearchResult, error) {
+	ctx, span := r.start(ctx, "Search", attribute.Int("user_id", userID), attribute.Int("limit", limit))
+	results, err := r.repo.Search(ctx, userID, query, limit)
+	span.SetAttributes(attribute.Int("events", len(results)))
+	endSpan(span, err)
+	return results, err
+}
+
+// ReplaceTag переименовывает тег во всех событиях пользователя
+func (r *TracedEventRepository) ReplaceTag(ctx context.Context, userID int, oldName, newName string) error {
+	ctx, span := r.start(ctx, "ReplaceTag", attribute.Int("user_id", userID))
+	err := r.repo.ReplaceTag(ctx, userID, oldName, newName)
+	endSpan(span, err)
+	return err
+}
+
+// WithTx выполняет fn в транзакции; вызовы внутри транзакции тоже трассируются
+func (r *TracedEventRepository) WithTx(ctx context.Context, fn func(tx domain.EventRepository) error) error {
+	ctx, span := r.start(ctx, "WithTx")
+	err := r.repo.WithTx(ctx, func(tx domain.EventRepository) error {
+		return fn(&TracedEventRepository{repo: tx, backend: r.backend, tracer: r.tracer, txSpan: span})
+	})
+	endSpan(span, err)
+	return err
+}
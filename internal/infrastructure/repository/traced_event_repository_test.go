@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracedEventRepository(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	repo := NewTracedEventRepository(NewMemoryEventRepository(), "memory")
+	ctx := context.Background()
+	date := time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC)
+
+	err := repo.WithTx(ctx, func(tx domain.EventRepository) error {
+		return tx.Create(ctx, &domain.Event{UserID: 1, Date: date, Text: "Встреча"})
+	})
+	assert.NoError(t, err)
+
+	_, err = repo.GetByID(ctx, 42)
+	assert.Error(t, err)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 3) {
+		create, tx, get := spans[0], spans[1], spans[2]
+		assert.Equal(t, "EventRepository.Create", create.Name())
+		assert.Equal(t, "EventRepository.WithTx", tx.Name())
+		// Вызов внутри транзакции относится к ее спану
+		assert.Equal(t, tx.SpanContext().SpanID(), create.Parent().SpanID())
+
+		assert.Equal(t, "EventRepository.GetByID", get.Name())
+		assert.Equal(t, codes.Error, get.Status().Code)
+	}
+}
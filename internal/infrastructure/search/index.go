@@ -0,0 +1,176 @@
+package search
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// prefixWeight - вес совпадения по префиксу относительно точного совпадения терма
+const prefixWeight = 0.5
+
+// document - проиндексированный текст события
+type document struct {
+	userID int
+	terms  map[string]int // терм -> число вхождений
+	length int
+}
+
+// Hit - найденный документ с оценкой релевантности
+type Hit struct {
+	ID    int
+	Score float64
+}
+
+// userIndex - часть индекса с документами одного пользователя
+type userIndex struct {
+	postings map[string]map[int]int // терм -> ID документа -> число вхождений
+	terms    []string               // термы по возрастанию, для поиска по префиксу
+	docs     int                    // число документов
+	length   int                    // суммарная длина документов в термах
+}
+
+// addTerm добавляет терм в упорядоченный список термов
+func (u *userIndex) addTerm(term string) {
+	i := sort.SearchStrings(u.terms, term)
+	u.terms = slices.Insert(u.terms, i, term)
+}
+
+// removeTerm удаляет терм из упорядоченного списка термов
+func (u *userIndex) removeTerm(term string) {
+	if i := sort.SearchStrings(u.terms, term); i < len(u.terms) && u.terms[i] == term {
+		u.terms = slices.Delete(u.terms, i, i+1)
+	}
+}
+
+// Index - инвертированный индекс текстов событий, разделенный по пользователям.
+// Индекс не потокобезопасен: синхронизация остается на стороне владельца.
+type Index struct {
+	docs  map[int]*document
+	users map[int]*userIndex
+}
+
+// NewIndex создает пустой индекс
+func NewIndex() *Index {
+	return &Index{
+		docs:  make(map[int]*document),
+		users: make(map[int]*userIndex),
+	}
+}
+
+// Add индексирует текст документа, заменяя предыдущую версию
+func (idx *Index) Add(id, userID int, text string) {
+	idx.Remove(id)
+
+	tokens := Tokenize(text)
+	doc := &document{userID: userID, terms: make(map[string]int), length: len(tokens)}
+	for _, token := range tokens {
+		doc.terms[token]++
+	}
+
+	user, ok := idx.users[userID]
+	if !ok {
+		user = &userIndex{postings: make(map[string]map[int]int)}
+		idx.users[userID] = user
+	}
+	for term, count := range doc.terms {
+		if user.postings[term] == nil {
+			user.postings[term] = make(map[int]int)
+			user.addTerm(term)
+		}
+		user.postings[term][id] = count
+	}
+	user.docs++
+	user.length += doc.length
+
+	idx.docs[id] = doc
+}
+
+// Remove удаляет документ из индекса
+func (idx *Index) Remove(id int) {
+	doc, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+
+	user := idx.users[doc.userID]
+	for term := range doc.terms {
+		delete(user.postings[term], id)
+		if len(user.postings[term]) == 0 {
+			delete(user.postings, term)
+			user.removeTerm(term)
+		}
+	}
+	user.docs--
+	user.length -= doc.length
+	if user.docs == 0 {
+		delete(idx.users, doc.userID)
+	}
+
+	delete(idx.docs, id)
+}
+
+// Search ищет документы пользователя, содержащие все термы запроса точно или как префикс,
+// и упорядочивает их по убыванию релевантности; точное совпадение весит больше префиксного
+func (idx *Index) Search(userID int, query string, limit int) []Hit {
+	user := idx.users[userID]
+	queryTerms := Tokenize(query)
+	if user == nil || len(queryTerms) == 0 {
+		return nil
+	}
+
+	avgLength := float64(user.length) / float64(user.docs)
+
+	var scores map[int]float64
+	for _, queryTerm := range queryTerms {
+		termScores := make(map[int]float64)
+
+		// Термы с префиксом queryTerm идут в упорядоченном списке подряд, начиная с самого queryTerm
+		for i := sort.SearchStrings(user.terms, queryTerm); i < len(user.terms) && strings.HasPrefix(user.terms[i], queryTerm); i++ {
+			term := user.terms[i]
+			docs := user.postings[term]
+			weight := 1.0
+			if term != queryTerm {
+				weight = prefixWeight
+			}
+
+			idf := math.Log(1 + float64(user.docs)/float64(len(docs)))
+			for id, count := range docs {
+				tf := float64(count) / (float64(count) + 0.5 + float64(idx.docs[id].length)/avgLength)
+				if score := weight * tf * idf; score > termScores[id] {
+					termScores[id] = score
+				}
+			}
+		}
+
+		// Документ должен содержать все термы запроса
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		for id, score := range scores {
+			if termScore, ok := termScores[id]; ok {
+				scores[id] = score + termScore
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{ID: id, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
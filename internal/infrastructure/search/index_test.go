@@ -0,0 +1,125 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hitIDs возвращает ID найденных документов в порядке ранжирования
+func hitIDs(hits []Hit) []int {
+	var ids []int
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{name: "Пустой текст", text: "", expected: []string{}},
+		{name: "Регистр и знаки препинания", text: "Стоматолог: повторно, ЁЛКА!", expected: []string{"стоматолог", "повторно", "елка"}},
+		{name: "Цифры и латиница", text: "Room 42-B", expected: []string{"room", "42", "b"}},
+		{name: "Только разделители", text: " - , ; ", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Tokenize(tt.text))
+		})
+	}
+}
+
+func TestIndex_Search(t *testing.T) {
+	idx := NewIndex()
+	idx.Add(1, 1, "Запись к стоматологу")
+	idx.Add(2, 1, "Стоматолог: стоматолог повторно, ЁЛКА")
+	idx.Add(3, 1, "Dentist appointment")
+	idx.Add(4, 2, "Стоматолог другого пользователя")
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []int
+	}{
+		// Точное совпадение весит больше префиксного
+		{name: "Точное совпадение выше префиксного", query: "стоматолог", expected: []int{2, 1}},
+		{name: "Регистр не важен", query: "СТОМАТ", expected: []int{2, 1}},
+		{name: "Все термы запроса обязательны", query: "стоматолог елка", expected: []int{2}},
+		{name: "Ё и е не различаются", query: "ёлка", expected: []int{2}},
+		{name: "Латиница", query: "dent", expected: []int{3}},
+		{name: "Нет совпадений", query: "терапевт", expected: nil},
+		{name: "Пустой запрос", query: " , ", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, hitIDs(idx.Search(1, tt.query, 10)))
+		})
+	}
+
+	// Документы других пользователей не находятся
+	assert.Equal(t, []int{4}, hitIDs(idx.Search(2, "стоматолог", 10)))
+	assert.Empty(t, idx.Search(3, "стоматолог", 10))
+
+	// Лимит применяется после ранжирования
+	assert.Equal(t, []int{2}, hitIDs(idx.Search(1, "стоматолог", 1)))
+}
+
+func TestIndex_Ranking(t *testing.T) {
+	idx := NewIndex()
+	// Больше вхождений терма - выше оценка
+	idx.Add(1, 1, "встреча")
+	idx.Add(2, 1, "встреча встреча")
+	// При равном числе вхождений короткий документ выше длинного
+	idx.Add(3, 1, "обед")
+	idx.Add(4, 1, "обед с коллегами из соседнего отдела")
+	// При равной оценке документы упорядочены по ID
+	idx.Add(5, 1, "планерка")
+	idx.Add(6, 1, "планерка")
+
+	assert.Equal(t, []int{2, 1}, hitIDs(idx.Search(1, "встреча", 10)))
+	assert.Equal(t, []int{3, 4}, hitIDs(idx.Search(1, "обед", 10)))
+	assert.Equal(t, []int{5, 6}, hitIDs(idx.Search(1, "планерка", 10)))
+
+	// Редкий терм весит больше частого
+	idx = NewIndex()
+	idx.Add(1, 1, "созвон с клиентом")
+	idx.Add(2, 1, "созвон с командой")
+	rare := idx.Search(1, "клиентом", 10)
+	common := idx.Search(1, "созвон", 10)
+	if assert.Len(t, rare, 1) && assert.Len(t, common, 2) {
+		assert.Greater(t, rare[0].Score, common[0].Score)
+	}
+}
+
+func TestIndex_AddRemove(t *testing.T) {
+	idx := NewIndex()
+	idx.Add(1, 1, "Запись к стоматологу")
+	idx.Add(2, 1, "Стоматолог повторно")
+
+	// Повторное добавление заменяет текст документа
+	idx.Add(1, 1, "Запись к терапевту")
+	assert.Equal(t, []int{2}, hitIDs(idx.Search(1, "стоматолог", 10)))
+	assert.Equal(t, []int{1}, hitIDs(idx.Search(1, "терап", 10)))
+
+	// Статистика пользователя и список термов обновляются
+	user := idx.users[1]
+	assert.Equal(t, 2, user.docs)
+	assert.Equal(t, 5, user.length)
+	assert.Equal(t, []string{"запись", "к", "повторно", "стоматолог", "терапевту"}, user.terms)
+
+	idx.Remove(1)
+	assert.Empty(t, idx.Search(1, "терапевту", 10))
+	assert.Equal(t, []string{"повторно", "стоматолог"}, idx.users[1].terms)
+
+	// Удаление последнего документа пользователя освобождает его часть индекса
+	idx.Remove(2)
+	idx.Remove(2)
+	assert.Empty(t, idx.users)
+	assert.Empty(t, idx.docs)
+}
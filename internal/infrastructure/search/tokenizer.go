@@ -0,0 +1,22 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// foldReplacer приводит варианты букв к единому написанию
+var foldReplacer = strings.NewReplacer("ё", "е")
+
+// Tokenize разбивает текст на термы: последовательности букв и цифр в нижнем регистре
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		tokens = append(tokens, foldReplacer.Replace(strings.ToLower(field)))
+	}
+	return tokens
+}
@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// Экспортеры трассировки
+const (
+	ExporterNone    = "none"    // трассировка выключена
+	ExporterConsole = "console" // вывод спанов в stderr, отдельно от журнала в stdout
+	ExporterOTLP    = "otlp"    // отправка по OTLP/HTTP, адрес задается переменными OTEL_EXPORTER_OTLP_*
+)
+
+// ServiceName - имя сервиса в трассах
+const ServiceName = "calendar"
+
+// Setup настраивает глобальный провайдер трассировки с экспортером exporter и распространение
+// контекста в заголовках W3C traceparent и baggage. Без экспортера (none или пустая строка)
+// остается провайдер по умолчанию, который ничего не записывает. Возвращает функцию, которая
+// отправляет накопленные спаны и останавливает провайдер.
+func Setup(ctx context.Context, exporter string) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var spanExporter sdktrace.SpanExporter
+	switch exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterConsole:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+	case ExporterOTLP:
+		spanExporter, err = otlptracehttp.New(ctx)
+	default:
+		return nil, fmt.Errorf("неизвестный экспортер трассировки %q, допустимы %s, %s и %s", exporter, ExporterNone, ExporterConsole, ExporterOTLP)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания экспортера трассировки: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
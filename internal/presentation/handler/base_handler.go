@@ -2,49 +2,122 @@ package handler
 
 import (
 	"calendar/internal/domain"
+	"calendar/internal/presentation/ical"
+	"calendar/internal/presentation/problem"
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
 	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // BaseHandler содержит общие методы для всех обработчиков
 type BaseHandler struct {
-	validator *RequestValidator
+	validator     *RequestValidator
+	errorObserver func(err *domain.AppError)
+	statusPolicy  StatusPolicy
 }
 
 // NewBaseHandler создает новый базовый обработчик
 func NewBaseHandler() *BaseHandler {
 	return &BaseHandler{
-		validator: NewRequestValidator(),
+		validator:    NewRequestValidator(),
+		statusPolicy: StatusPolicyStrict,
 	}
 }
 
-// writeResponse записывает JSON-ответ
-func (h *BaseHandler) writeResponse(w http.ResponseWriter, statusCode int, response domain.Response) {
+// writeResponse записывает JSON-ответ. Заголовки к моменту кодирования уже отправлены,
+// поэтому ошибка кодирования или записи только журналируется.
+func (h *BaseHandler) writeResponse(w http.ResponseWriter, r *http.Request, statusCode int, response domain.Response) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(response)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.ErrorContext(r.Context(), "ошибка записи JSON-ответа", "error", err)
+	}
 }
 
-// writeError записывает ошибку
-func (h *BaseHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
-	h.writeResponse(w, statusCode, domain.Response{Error: message})
+// writeSuccess записывает успешный ответ
+func (h *BaseHandler) writeSuccess(w http.ResponseWriter, r *http.Request, data interface{}) {
+	h.writeResponse(w, r, http.StatusOK, domain.Response{Result: data})
 }
 
-// writeSuccess записывает успешный ответ
-func (h *BaseHandler) writeSuccess(w http.ResponseWriter, data interface{}) {
-	h.writeResponse(w, http.StatusOK, domain.Response{Result: data})
+// writeSuccessWithConflicts записывает успешный ответ с предупреждением о пересечениях
+func (h *BaseHandler) writeSuccessWithConflicts(w http.ResponseWriter, r *http.Request, data interface{}, conflicts []*domain.Event) {
+	h.writeResponse(w, r, http.StatusOK, domain.Response{Result: data, Conflicts: conflicts})
+}
+
+// writePage записывает страницу событий: события в result, курсор следующей страницы в next_cursor
+func (h *BaseHandler) writePage(w http.ResponseWriter, r *http.Request, page *domain.EventPage) {
+	h.writeResponse(w, r, http.StatusOK, domain.Response{Result: page.Events, NextCursor: page.NextCursor})
+}
+
+// writeCalendar записывает ответ в формате iCalendar функцией write. Заголовки к моменту
+// записи уже отправлены, поэтому ошибка записи (обычно обрыв соединения) только журналируется.
+func (h *BaseHandler) writeCalendar(w http.ResponseWriter, r *http.Request, write func(io.Writer) error) {
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.WriteHeader(http.StatusOK)
+	if err := write(w); err != nil {
+		slog.WarnContext(r.Context(), "ошибка записи ответа iCalendar", "error", err)
+	}
 }
 
-// handleError обрабатывает ошибки и возвращает соответствующий HTTP статус-код
-func (h *BaseHandler) handleError(w http.ResponseWriter, err error) {
-	if appErr, ok := err.(*domain.AppError); ok {
-		// Это наша типизированная ошибка
-		h.writeError(w, appErr.GetStatusCode(), appErr.Error())
+// handleError обрабатывает ошибки и возвращает соответствующий HTTP статус-код.
+// Ошибки сервера записываются в журнал вместе с причиной, которая не попадает в ответ.
+// Формат ответа с ошибкой выбирается по заголовку Accept, см. problem.WriteError.
+func (h *BaseHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	trace.SpanFromContext(r.Context()).RecordError(err)
+
+	// Запрос отменен клиентом или прерван по таймауту
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		slog.WarnContext(r.Context(), "запрос прерван", "error", err)
+		problem.WriteError(w, r, domain.NewAppError("Запрос отменен или превысил время ожидания", http.StatusServiceUnavailable, nil).WithCode(domain.CodeRequestCanceled))
+		return
+	}
+
+	// Ошибка приложения может быть обернута, например через fmt.Errorf("...: %w", err)
+	var appErr *domain.AppError
+	if errors.As(err, &appErr) {
+		if h.errorObserver != nil {
+			h.errorObserver(appErr)
+		}
+		if appErr.GetStatusCode() >= http.StatusInternalServerError {
+			slog.ErrorContext(r.Context(), appErr.Message, "error", err)
+		}
+		// Это наша типизированная ошибка; статус-код ответа определяет политика статус-кодов
+		if status := h.statusPolicy.Status(appErr.GetStatusCode(), appErr.ErrorCode()); status != appErr.GetStatusCode() {
+			// Код ошибки фиксируется до замены статус-кода, от которого зависит код по умолчанию
+			copied := *appErr
+			copied.Code = appErr.ErrorCode()
+			copied.StatusCode = status
+			appErr = &copied
+		}
+
+		// Отказ из-за пересечений сопровождается списком пересекающихся событий
+		var conflictErr *domain.ConflictError
+		if errors.As(err, &conflictErr) {
+			problem.WriteError(w, r, appErr, conflictErr.Conflicts...)
+			return
+		}
+		problem.WriteError(w, r, appErr)
 		return
 	}
 
 	// Неизвестная ошибка - возвращаем 500
-	h.writeError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
+	slog.ErrorContext(r.Context(), "необработанная ошибка", "error", err)
+	problem.WriteError(w, r, domain.NewInternalError("Внутренняя ошибка сервера", nil))
+}
+
+// ObserveErrors задает функцию, которая вызывается для каждой ошибки приложения, возвращаемой клиенту
+func (h *BaseHandler) ObserveErrors(observer func(err *domain.AppError)) {
+	h.errorObserver = observer
+}
+
+// SetStatusPolicy задает политику статус-кодов ответов с ошибками бизнес-логики
+func (h *BaseHandler) SetStatusPolicy(policy StatusPolicy) {
+	h.statusPolicy = policy
 }
 
 // GetValidator возвращает валидатор запросов
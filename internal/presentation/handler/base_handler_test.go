@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"calendar/internal/domain"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleError_Conflicts(t *testing.T) {
+	conflicts := []*domain.Event{
+		{ID: 7, UserID: 1, Date: time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC), Text: "Встреча"},
+	}
+	err := fmt.Errorf("создание: %w", domain.NewConflictError("событие пересекается с существующими событиями", conflicts))
+
+	h := NewBaseHandler()
+	recorder := httptest.NewRecorder()
+	h.handleError(recorder, httptest.NewRequest(http.MethodPost, "/create_event", nil), err)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+
+	var response domain.Response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, domain.CodeEventConflict, response.Code)
+	require.Len(t, response.Conflicts, 1)
+	assert.Equal(t, 7, response.Conflicts[0].ID)
+
+	// Без пересечений поле conflicts в ответ не попадает
+	recorder = httptest.NewRecorder()
+	h.handleError(recorder, httptest.NewRequest(http.MethodPost, "/create_event", nil), domain.NewNotFoundError("событие не найдено"))
+	assert.NotContains(t, recorder.Body.String(), "conflicts")
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"calendar/internal/domain"
+	"calendar/internal/infrastructure/i18n"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// batchRequest - тело запроса /batch
+type batchRequest struct {
+	UserID         int              `json:"user_id"`
+	Atomic         bool             `json:"atomic"`
+	ConflictPolicy string           `json:"conflict_policy"`
+	Operations     []batchOperation `json:"operations"`
+}
+
+// batchOperation - операция пакета в формате запроса; даты задаются строками, как в формах
+type batchOperation struct {
+	Op          string            `json:"op"`
+	ID          int               `json:"id"`
+	Date        string            `json:"date"`
+	EndDate     string            `json:"end_date"`
+	Text        string            `json:"text"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Location    *domain.Location  `json:"location"`
+	URL         string            `json:"url"`
+	Properties  map[string]string `json:"properties"`
+	Tags        []string          `json:"tags"`
+}
+
+// ApplyBatch выполняет пакет операций создания, изменения и удаления событий
+func (h *EventHandler) ApplyBatch(w http.ResponseWriter, r *http.Request) {
+	v := h.GetValidator()
+
+	var req batchRequest
+	if err := v.DecodeJSONBody(r, &req); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	if req.UserID <= 0 {
+		h.handleError(w, r, domain.NewFieldError("user_id", domain.CodeInvalidParameter, "некорректный user_id"))
+		return
+	}
+
+	loc, err := h.resolveLocation(r, req.UserID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	policy, err := v.ParseConflictPolicy(req.ConflictPolicy)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	operations := make([]domain.BatchOperation, len(req.Operations))
+	for i, op := range req.Operations {
+		if operations[i], err = parseBatchOperation(v, op, loc); err != nil {
+			h.handleError(w, r, batchOperationError(i, err))
+			return
+		}
+	}
+
+	result, err := h.eventService.ApplyBatch(r.Context(), req.UserID, operations, policy, req.Atomic)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	lang := i18n.Language(r.Context())
+	for _, item := range result.Items {
+		item.Event = eventInLocation(item.Event, loc)
+		item.Conflicts = eventsInLocation(item.Conflicts, loc)
+		if item.Code != "" {
+			item.Error, item.Details = i18n.Localize(lang, &domain.AppError{
+				Message:    item.Error,
+				StatusCode: item.Status,
+				Code:       item.Code,
+				Fields:     item.Details,
+			})
+		}
+		item.Status = h.statusPolicy.Status(item.Status, item.Code)
+	}
+
+	h.writeSuccess(w, r, result)
+}
+
+// batchOperationError возвращает ошибку разбора операции index; код ошибки сохраняется,
+// а поля получают префикс operations[index]
+func batchOperationError(index int, err error) error {
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) {
+		return err
+	}
+
+	opErr := domain.NewValidationError(fmt.Sprintf("операция %d: %s", index, appErr.Error())).WithCode(appErr.ErrorCode())
+	for _, field := range appErr.Fields {
+		field.Field = fmt.Sprintf("operations[%d].%s", index, field.Field)
+		opErr.Fields = append(opErr.Fields, field)
+	}
+	return opErr
+}
+
+// parseBatchOperation преобразует операцию запроса в операцию сервиса, разбирая даты в часовом поясе loc.
+// Вид операции проверяется первым, чтобы ошибка указывала на op, а не на даты
+func parseBatchOperation(v *RequestValidator, op batchOperation, loc *time.Location) (domain.BatchOperation, error) {
+	result := domain.BatchOperation{Type: domain.ChangeType(op.Op), ID: op.ID}
+	switch result.Type {
+	case domain.ChangeCreate, domain.ChangeUpdate:
+	case domain.ChangeDelete:
+		return result, nil
+	case "":
+		return result, domain.NewFieldError("op", domain.CodeMissingParameter, "параметр op обязателен")
+	default:
+		return result, domain.NewFieldError("op", domain.CodeInvalidParameter, "неизвестная операция "+op.Op+": допустимы create, update и delete").
+			WithDetails(domain.MsgUnknownBatchOperation, domain.MessageParams{"value": op.Op})
+	}
+
+	date, err := v.ParseAndValidateDateTime("date", op.Date, loc)
+	if err != nil {
+		return result, err
+	}
+
+	endDate, err := v.ParseOptionalDateTime("end_date", op.EndDate, loc)
+	if err != nil {
+		return result, err
+	}
+
+	result.Input = domain.EventInput{
+		Date:        date,
+		EndDate:     endDate,
+		Text:        op.Text,
+		Title:       strings.TrimSpace(op.Title),
+		Description: op.Description,
+		Location:    op.Location,
+		URL:         strings.TrimSpace(op.URL),
+		Properties:  op.Properties,
+		Tags:        op.Tags,
+	}
+	return result, nil
+}
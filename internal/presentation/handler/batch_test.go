@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"calendar/internal/domain"
+	"calendar/internal/infrastructure/i18n"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyBatch_LocalizedItems(t *testing.T) {
+	body := `{"user_id": 1, "operations": [
+		{"op": "create", "date": "2025-12-18T10:00", "text": "Встреча"},
+		{"op": "create", "date": "2025-12-18T12:00", "text": "Обед", "location": {"geo": {"lat": 91, "lon": 0}}},
+		{"op": "delete", "id": 100}
+	]}`
+
+	tests := []struct {
+		lang            string
+		expectedError   string
+		expectedDetails []domain.FieldError
+		expectedMissing string
+	}{
+		{
+			lang:            domain.LanguageRussian,
+			expectedError:   "широта должна быть в диапазоне от -90 до 90",
+			expectedDetails: []domain.FieldError{{Field: "lat", Code: domain.CodeInvalidParameter, Message: "широта должна быть в диапазоне от -90 до 90"}},
+			expectedMissing: "событие не найдено",
+		},
+		{
+			lang:            domain.LanguageEnglish,
+			expectedError:   "invalid value of parameter lat",
+			expectedDetails: []domain.FieldError{{Field: "lat", Code: domain.CodeInvalidParameter, Message: "invalid value of parameter lat"}},
+			expectedMissing: "Event not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(body))
+			request.Header.Set("Content-Type", "application/json")
+			request = request.WithContext(i18n.WithLanguage(request.Context(), tt.lang))
+			recorder := httptest.NewRecorder()
+			newTestRouter().ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			var response struct {
+				Result domain.BatchResult `json:"result"`
+			}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			items := response.Result.Items
+			require.Len(t, items, 3)
+
+			assert.Equal(t, domain.StatusOK, items[0].Status)
+			assert.Empty(t, items[0].Error)
+			assert.Empty(t, items[0].Details)
+
+			// Ошибка в поле операции сохраняет поле и переводится вместе с ним
+			assert.Equal(t, domain.StatusBadRequest, items[1].Status)
+			assert.Equal(t, domain.CodeInvalidParameter, items[1].Code)
+			assert.Equal(t, tt.expectedError, items[1].Error)
+			assert.Equal(t, tt.expectedDetails, items[1].Details)
+
+			assert.Equal(t, domain.StatusNotFound, items[2].Status)
+			assert.Equal(t, domain.CodeEventNotFound, items[2].Code)
+			assert.Equal(t, tt.expectedMissing, items[2].Error)
+			assert.Empty(t, items[2].Details)
+		})
+	}
+}
+
+func TestApplyBatch_InvalidOperationType(t *testing.T) {
+	tests := []struct {
+		name          string
+		operation     string
+		expectedCode  domain.ErrorCode
+		expectedError string
+	}{
+		{
+			name:          "Неизвестная операция без дат",
+			operation:     `{"op": "move", "id": 1}`,
+			expectedCode:  domain.CodeInvalidParameter,
+			expectedError: "неизвестная операция move",
+		},
+		{
+			name:          "Неизвестная операция с некорректной датой",
+			operation:     `{"op": "Create", "date": "завтра", "text": "Встреча"}`,
+			expectedCode:  domain.CodeInvalidParameter,
+			expectedError: "неизвестная операция Create",
+		},
+		{
+			name:          "Операция не указана",
+			operation:     `{"date": "2025-12-18T10:00", "text": "Встреча"}`,
+			expectedCode:  domain.CodeMissingParameter,
+			expectedError: "параметр op обязателен",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"user_id": 1, "operations": [{"op": "delete", "id": 100}, ` + tt.operation + `]}`
+			request := httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(body))
+			request.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			newTestRouter().ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+			var response domain.Response
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.Contains(t, response.Error, "операция 1")
+			assert.Contains(t, response.Error, tt.expectedError)
+			require.Len(t, response.Details, 1)
+			assert.Equal(t, "operations[1].op", response.Details[0].Field)
+			assert.Equal(t, tt.expectedCode, response.Details[0].Code)
+		})
+	}
+}
@@ -3,23 +3,46 @@ package handler
 import (
 	"calendar/internal/application"
 	"calendar/internal/domain"
+	"calendar/internal/infrastructure/i18n"
+	"calendar/internal/presentation/ical"
+	"context"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 )
 
+// Режимы недельной выборки
+const (
+	weekModeRolling  = "rolling"
+	weekModeCalendar = "calendar"
+)
+
+// Значения по умолчанию для поиска времени встречи
+const (
+	defaultWorkDayStart = 9 * time.Hour
+	defaultWorkDayEnd   = 18 * time.Hour
+	defaultSlotStep     = 15 * time.Minute
+	defaultSlotLimit    = 10
+	defaultAgendaLimit  = 10
+	defaultSearchLimit  = 20
+)
+
 // EventHandler обрабатывает HTTP-запросы для событий
 type EventHandler struct {
 	*BaseHandler
-	eventService *application.EventService
+	eventService    *application.EventService
+	settingsService *application.UserSettingsService
 }
 
 // NewEventHandler создает новый экземпляр обработчика событий
-func NewEventHandler(eventService *application.EventService) *EventHandler {
+func NewEventHandler(eventService *application.EventService, settingsService *application.UserSettingsService) *EventHandler {
 	return &EventHandler{
-		BaseHandler:  NewBaseHandler(),
-		eventService: eventService,
+		BaseHandler:     NewBaseHandler(),
+		eventService:    eventService,
+		settingsService: settingsService,
 	}
 }
 
@@ -28,9 +51,17 @@ func (h *EventHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/create_event", h.CreateEvent).Methods("POST")
 	router.HandleFunc("/update_event", h.UpdateEvent).Methods("POST")
 	router.HandleFunc("/delete_event", h.DeleteEvent).Methods("POST")
+	router.HandleFunc("/quick_add", h.QuickAdd).Methods("POST")
+	router.HandleFunc("/batch", h.ApplyBatch).Methods("POST")
 	router.HandleFunc("/events_for_day", h.GetEventsForDay).Methods("GET")
 	router.HandleFunc("/events_for_week", h.GetEventsForWeek).Methods("GET")
 	router.HandleFunc("/events_for_month", h.GetEventsForMonth).Methods("GET")
+	router.HandleFunc("/events_for_year", h.GetEventsForYear).Methods("GET")
+	router.HandleFunc("/events", h.GetEventsForRange).Methods("GET")
+	router.HandleFunc("/agenda", h.GetAgenda).Methods("GET")
+	router.HandleFunc("/search", h.SearchEvents).Methods("GET")
+	router.HandleFunc("/freebusy", h.GetFreeBusy).Methods("GET")
+	router.HandleFunc("/find_slots", h.FindSlots).Methods("POST")
 }
 
 // CreateEvent создает новое событие
@@ -38,33 +69,60 @@ func (h *EventHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 	// Парсим и валидируем форму
 	fields, err := h.GetValidator().ParseFormAndValidate(r, []string{"user_id", "date", "text"})
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
 	// Парсим и валидируем параметры
 	userID, err := h.GetValidator().ParseAndValidateUserID(fields["user_id"])
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
+		return
+	}
+
+	loc, err := h.resolveLocation(r, userID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	date, err := h.GetValidator().ParseAndValidateDateTime("date", fields["date"], loc)
+	if err != nil {
+		h.handleError(w, r, err)
 		return
 	}
 
-	date, err := h.GetValidator().ParseAndValidateDate(fields["date"])
+	endDate, err := h.GetValidator().ParseOptionalDateTime("end_date", r.FormValue("end_date"), loc)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
-	text := fields["text"]
+	input := domain.EventInput{
+		Date:    date,
+		EndDate: endDate,
+		Text:    fields["text"],
+		Tags:    h.GetValidator().ParseTags(r.FormValue("tags")),
+	}
+	if err := h.parseEventDetails(r, &input); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	policy, err := h.GetValidator().ParseConflictPolicy(r.FormValue("conflict_policy"))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
 
 	// Создаем событие
-	event, err := h.eventService.CreateEvent(userID, date, text)
+	event, conflicts, err := h.eventService.CreateEvent(r.Context(), userID, input, policy)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, conflictsInLocation(err, loc))
 		return
 	}
 
-	h.writeSuccess(w, event)
+	h.writeSuccessWithConflicts(w, r, eventInLocation(event, loc), eventsInLocation(conflicts, loc))
 }
 
 // UpdateEvent обновляет существующее событие
@@ -72,39 +130,132 @@ func (h *EventHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	// Парсим и валидируем форму
 	fields, err := h.GetValidator().ParseFormAndValidate(r, []string{"id", "user_id", "date", "text"})
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
 	// Парсим и валидируем параметры
 	id, err := h.GetValidator().ParseAndValidateID(fields["id"])
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
 	userID, err := h.GetValidator().ParseAndValidateUserID(fields["user_id"])
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
+		return
+	}
+
+	loc, err := h.resolveLocation(r, userID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	date, err := h.GetValidator().ParseAndValidateDateTime("date", fields["date"], loc)
+	if err != nil {
+		h.handleError(w, r, err)
 		return
 	}
 
-	date, err := h.GetValidator().ParseAndValidateDate(fields["date"])
+	endDate, err := h.GetValidator().ParseOptionalDateTime("end_date", r.FormValue("end_date"), loc)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
-	text := fields["text"]
+	input := domain.EventInput{
+		Date:    date,
+		EndDate: endDate,
+		Text:    fields["text"],
+		Tags:    h.GetValidator().ParseTags(r.FormValue("tags")),
+	}
+	if err := h.parseEventDetails(r, &input); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	policy, err := h.GetValidator().ParseConflictPolicy(r.FormValue("conflict_policy"))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
 
 	// Обновляем событие
-	event, err := h.eventService.UpdateEvent(id, userID, date, text)
+	event, conflicts, err := h.eventService.UpdateEvent(r.Context(), id, userID, input, policy)
+	if err != nil {
+		h.handleError(w, r, conflictsInLocation(err, loc))
+		return
+	}
+
+	h.writeSuccessWithConflicts(w, r, eventInLocation(event, loc), eventsInLocation(conflicts, loc))
+}
+
+// QuickAdd разбирает фразу на естественном языке в событие; с save=true событие сохраняется
+func (h *EventHandler) QuickAdd(w http.ResponseWriter, r *http.Request) {
+	// Парсим и валидируем форму
+	fields, err := h.GetValidator().ParseFormAndValidate(r, []string{"user_id", "text"})
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	userID, err := h.GetValidator().ParseAndValidateUserID(fields["user_id"])
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	// Относительные даты ("завтра", "в пятницу") вычисляются в часовом поясе пользователя
+	loc, err := h.resolveLocation(r, userID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	save, err := h.GetValidator().ParseOptionalBool("save", r.FormValue("save"))
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
-	h.writeSuccess(w, event)
+	policy, err := h.GetValidator().ParseConflictPolicy(r.FormValue("conflict_policy"))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	result, conflicts, err := h.eventService.QuickAdd(r.Context(), userID, fields["text"], loc, save, policy)
+	if err != nil {
+		h.handleError(w, r, conflictsInLocation(err, loc))
+		return
+	}
+
+	result.Event = eventInLocation(result.Event, loc)
+	h.writeSuccessWithConflicts(w, r, result, eventsInLocation(conflicts, loc))
+}
+
+// parseEventDetails заполняет заголовок, описание, место, ссылку и пользовательские свойства события из формы
+func (h *EventHandler) parseEventDetails(r *http.Request, input *domain.EventInput) error {
+	v := h.GetValidator()
+
+	location, err := v.ParseEventLocation(r.FormValue("location"), r.FormValue("lat"), r.FormValue("lon"))
+	if err != nil {
+		return err
+	}
+
+	properties, err := v.ParseEventProperties(r.Form)
+	if err != nil {
+		return err
+	}
+
+	input.Title = strings.TrimSpace(r.FormValue("title"))
+	input.Description = r.FormValue("description")
+	input.Location = location
+	input.URL = strings.TrimSpace(r.FormValue("url"))
+	input.Properties = properties
+	return nil
 }
 
 // DeleteEvent удаляет событие
@@ -112,65 +263,91 @@ func (h *EventHandler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 	// Парсим и валидируем форму
 	fields, err := h.GetValidator().ParseFormAndValidate(r, []string{"id", "user_id"})
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
 	// Парсим и валидируем параметры
 	id, err := h.GetValidator().ParseAndValidateID(fields["id"])
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
 	userID, err := h.GetValidator().ParseAndValidateUserID(fields["user_id"])
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
 	// Удаляем событие
-	err = h.eventService.DeleteEvent(id, userID)
+	err = h.eventService.DeleteEvent(r.Context(), id, userID)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
-	h.writeSuccess(w, map[string]string{"message": "Событие успешно удалено"})
+	h.writeSuccess(w, r, map[string]string{"message": i18n.NoticeMessage(i18n.Language(r.Context()), i18n.NoticeEventDeleted)})
+}
+
+// writeEvents записывает страницу событий в JSON или, при format=ics, как iCalendar.
+// В формате iCalendar курсор следующей страницы передается в заголовке X-Next-Cursor.
+func (h *EventHandler) writeEvents(w http.ResponseWriter, r *http.Request, page *domain.EventPage, loc *time.Location) {
+	if r.URL.Query().Get("format") == "ics" {
+		if page.NextCursor != "" {
+			w.Header().Set("X-Next-Cursor", page.NextCursor)
+		}
+		h.writeCalendar(w, r, func(out io.Writer) error { return ical.WriteEvents(out, page.Events) })
+		return
+	}
+
+	h.writePage(w, r, pageInLocation(page, loc))
 }
 
 // getEventsByDateRange общий метод для получения событий по диапазону дат
-func (h *EventHandler) getEventsByDateRange(w http.ResponseWriter, r *http.Request, getter func(int, time.Time) ([]*domain.Event, error)) {
+func (h *EventHandler) getEventsByDateRange(w http.ResponseWriter, r *http.Request, getter func(context.Context, int, time.Time, domain.PageRequest) (*domain.EventPage, error)) {
 	// Извлекаем параметры из query string
 	userIDStr := r.URL.Query().Get("user_id")
 	dateStr := r.URL.Query().Get("date")
 
 	if userIDStr == "" || dateStr == "" {
-		h.writeError(w, http.StatusBadRequest, "Необходимы параметры: user_id, date")
+		h.handleError(w, r, domain.NewValidationError("Необходимы параметры: user_id, date").WithCode(domain.CodeMissingParameter))
 		return
 	}
 
 	// Парсим и валидируем параметры
 	userID, err := h.GetValidator().ParseAndValidateUserID(userIDStr)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
+		return
+	}
+
+	loc, err := h.resolveLocation(r, userID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	date, err := h.GetValidator().ParseAndValidateDate(dateStr, loc)
+	if err != nil {
+		h.handleError(w, r, err)
 		return
 	}
 
-	date, err := h.GetValidator().ParseAndValidateDate(dateStr)
+	page, err := h.GetValidator().ParsePageRequest(r, loc)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
 	// Получаем события
-	events, err := getter(userID, date)
+	events, err := getter(r.Context(), userID, date, page)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
-	h.writeSuccess(w, events)
+	h.writeEvents(w, r, events, loc)
 }
 
 // GetEventsForDay возвращает события на день
@@ -178,9 +355,81 @@ func (h *EventHandler) GetEventsForDay(w http.ResponseWriter, r *http.Request) {
 	h.getEventsByDateRange(w, r, h.eventService.GetEventsForDay)
 }
 
-// GetEventsForWeek возвращает события на неделю
+// GetEventsForWeek возвращает события на неделю: 7 дней от даты или календарную неделю, содержащую ее.
+// Вместо даты можно передать идентификатор недели ISO 8601 (например, 2025-W51).
 func (h *EventHandler) GetEventsForWeek(w http.ResponseWriter, r *http.Request) {
-	h.getEventsByDateRange(w, r, h.eventService.GetEventsForWeek)
+	query := r.URL.Query()
+	userIDStr := query.Get("user_id")
+	dateStr := query.Get("date")
+
+	if userIDStr == "" || dateStr == "" {
+		h.handleError(w, r, domain.NewValidationError("Необходимы параметры: user_id, date").WithCode(domain.CodeMissingParameter))
+		return
+	}
+
+	// Парсим и валидируем параметры
+	userID, err := h.GetValidator().ParseAndValidateUserID(userIDStr)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	mode, err := h.GetValidator().ParseWeekMode(query.Get("mode"))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	loc, err := h.resolveLocation(r, userID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	page, err := h.GetValidator().ParsePageRequest(r, loc)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	// Неделя ISO всегда начинается с понедельника
+	date, isISOWeek, err := h.GetValidator().ParseISOWeek(dateStr, loc)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	var events *domain.EventPage
+	switch {
+	case isISOWeek:
+		events, err = h.eventService.GetEventsForWeek(r.Context(), userID, date, page)
+	case mode == weekModeCalendar:
+		if date, err = h.GetValidator().ParseAndValidateDate(dateStr, loc); err != nil {
+			h.handleError(w, r, err)
+			return
+		}
+
+		var weekStart time.Weekday
+		if weekStart, err = h.resolveWeekStart(r, userID); err != nil {
+			h.handleError(w, r, err)
+			return
+		}
+
+		events, err = h.eventService.GetEventsForCalendarWeek(r.Context(), userID, date, weekStart, page)
+	default:
+		if date, err = h.GetValidator().ParseAndValidateDate(dateStr, loc); err != nil {
+			h.handleError(w, r, err)
+			return
+		}
+
+		events, err = h.eventService.GetEventsForWeek(r.Context(), userID, date, page)
+	}
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	h.writeEvents(w, r, events, loc)
 }
 
 // GetEventsForMonth возвращает события на месяц
@@ -190,29 +439,319 @@ func (h *EventHandler) GetEventsForMonth(w http.ResponseWriter, r *http.Request)
 	yearMonthStr := r.URL.Query().Get("date")
 
 	if userIDStr == "" || yearMonthStr == "" {
-		h.writeError(w, http.StatusBadRequest, "Необходимы параметры: user_id, date")
+		h.handleError(w, r, domain.NewValidationError("Необходимы параметры: user_id, date").WithCode(domain.CodeMissingParameter))
 		return
 	}
 
 	// Парсим и валидируем параметры
 	userID, err := h.GetValidator().ParseAndValidateUserID(userIDStr)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
+		return
+	}
+
+	loc, err := h.resolveLocation(r, userID)
+	if err != nil {
+		h.handleError(w, r, err)
 		return
 	}
 
-	yearMonth, err := h.GetValidator().ParseAndValidateYearMonth(yearMonthStr)
+	yearMonth, err := h.GetValidator().ParseAndValidateYearMonth(yearMonthStr, loc)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
+		return
+	}
+
+	page, err := h.GetValidator().ParsePageRequest(r, loc)
+	if err != nil {
+		h.handleError(w, r, err)
 		return
 	}
 
 	// Получаем события
-	events, err := h.eventService.GetEventsForMonth(userID, yearMonth)
+	events, err := h.eventService.GetEventsForMonth(r.Context(), userID, yearMonth, page)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	h.writeEvents(w, r, events, loc)
+}
+
+// GetEventsForYear возвращает события года, сгруппированные по месяцам
+func (h *EventHandler) GetEventsForYear(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	userID, err := h.GetValidator().ParseAndValidateUserID(query.Get("user_id"))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	loc, err := h.resolveLocation(r, userID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	year, err := h.GetValidator().ParseAndValidateYear(query.Get("date"), loc)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	summary, err := h.eventService.GetEventsForYear(r.Context(), userID, year)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	for _, month := range summary {
+		month.Events = eventsInLocation(month.Events, loc)
+	}
+
+	h.writeSuccess(w, r, summary)
+}
+
+// GetEventsForRange возвращает события в произвольном периоде [from, to)
+func (h *EventHandler) GetEventsForRange(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	userID, err := h.GetValidator().ParseAndValidateUserID(query.Get("user_id"))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	loc, err := h.resolveLocation(r, userID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	from, err := h.GetValidator().ParseAndValidateDateTime("from", query.Get("from"), loc)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	to, err := h.GetValidator().ParseAndValidateDateTime("to", query.Get("to"), loc)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	page, err := h.GetValidator().ParsePageRequest(r, loc)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	events, err := h.eventService.GetEventsForRange(r.Context(), userID, from, to, page)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	h.writeEvents(w, r, events, loc)
+}
+
+// GetAgenda возвращает ближайшие предстоящие события пользователя
+func (h *EventHandler) GetAgenda(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	userID, err := h.GetValidator().ParseAndValidateUserID(query.Get("user_id"))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	loc, err := h.resolveLocation(r, userID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	from, err := h.GetValidator().ParseOptionalDateTime("from", query.Get("from"), loc)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	if from.IsZero() {
+		from = time.Now()
+	}
+
+	page, err := h.GetValidator().ParsePageRequest(r, loc)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	if page.Limit == 0 {
+		page.Limit = defaultAgendaLimit
+	}
+
+	events, err := h.eventService.GetAgenda(r.Context(), userID, from, page)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	h.writeEvents(w, r, events, loc)
+}
+
+// SearchEvents ищет события пользователя по тексту
+func (h *EventHandler) SearchEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	userID, err := h.GetValidator().ParseAndValidateUserID(query.Get("user_id"))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	q := query.Get("q")
+	if q == "" {
+		h.handleError(w, r, domain.NewFieldError("q", domain.CodeMissingParameter, "параметр q обязателен"))
+		return
+	}
+
+	limit, err := h.GetValidator().ParseOptionalInt("limit", query.Get("limit"), defaultSearchLimit)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	loc, err := h.resolveLocation(r, userID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	results, err := h.eventService.SearchEvents(r.Context(), userID, q, limit)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	for i, result := range results {
+		results[i] = &domain.SearchResult{Event: eventInLocation(result.Event, loc), Score: result.Score}
+	}
+
+	h.writeSuccess(w, r, results)
+}
+
+// GetFreeBusy возвращает занятость нескольких пользователей без содержимого событий
+func (h *EventHandler) GetFreeBusy(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	userIDs, err := h.GetValidator().ParseAndValidateUserIDs("users", query.Get("users"))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	loc, err := h.requestLocation(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	from, err := h.GetValidator().ParseAndValidateDateTime("from", query.Get("from"), loc)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	to, err := h.GetValidator().ParseAndValidateDateTime("to", query.Get("to"), loc)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	freeBusy, err := h.eventService.GetFreeBusy(r.Context(), userIDs, from, to)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	if query.Get("format") == "ics" {
+		h.writeCalendar(w, r, func(out io.Writer) error { return ical.WriteFreeBusy(out, from, to, freeBusy) })
+		return
+	}
+
+	freeBusyInLocation(freeBusy, loc)
+	h.writeSuccess(w, r, freeBusy)
+}
+
+// FindSlots подбирает время встречи для нескольких участников
+func (h *EventHandler) FindSlots(w http.ResponseWriter, r *http.Request) {
+	// Парсим и валидируем форму
+	fields, err := h.GetValidator().ParseFormAndValidate(r, []string{"participants", "duration", "from", "to"})
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	v := h.GetValidator()
+	query := domain.SlotQuery{}
+
+	// Рабочие часы и границы поиска интерпретируются в часовом поясе tz
+	loc, err := h.requestLocation(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	if query.Participants, err = v.ParseAndValidateUserIDs("participants", fields["participants"]); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	if query.Duration, err = v.ParseAndValidateDuration("duration", fields["duration"]); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	if query.From, err = v.ParseAndValidateDateTime("from", fields["from"], loc); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	if query.To, err = v.ParseAndValidateDateTime("to", fields["to"], loc); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	if query.WorkDayStart, err = v.ParseOptionalClock("work_start", r.FormValue("work_start"), defaultWorkDayStart); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	if query.WorkDayEnd, err = v.ParseOptionalClock("work_end", r.FormValue("work_end"), defaultWorkDayEnd); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	if query.IncludeWeekends, err = v.ParseOptionalBool("include_weekends", r.FormValue("include_weekends")); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	if query.MinNotice, err = v.ParseOptionalDuration("min_notice", r.FormValue("min_notice"), 0); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	if query.Quorum, err = v.ParseOptionalInt("quorum", r.FormValue("quorum"), len(query.Participants)); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	if query.Step, err = v.ParseOptionalDuration("step", r.FormValue("step"), defaultSlotStep); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+	if query.Limit, err = v.ParseOptionalInt("limit", r.FormValue("limit"), defaultSlotLimit); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	slots, err := h.eventService.FindSlots(r.Context(), query)
 	if err != nil {
-		h.handleError(w, err)
+		h.handleError(w, r, err)
 		return
 	}
 
-	h.writeSuccess(w, events)
+	h.writeSuccess(w, r, slots)
 }
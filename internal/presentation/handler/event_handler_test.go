@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"calendar/internal/application"
+	"calendar/internal/domain"
+	"calendar/internal/infrastructure/i18n"
+	"calendar/internal/infrastructure/repository"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRouter создает маршрутизатор с обработчиком событий на хранилищах в памяти
+func newTestRouter() *mux.Router {
+	eventService := application.NewEventService(repository.NewMemoryEventRepository(), repository.NewMemoryTagRepository())
+	settingsService := application.NewUserSettingsService(repository.NewMemoryUserSettingsRepository())
+
+	router := mux.NewRouter()
+	NewEventHandler(eventService, settingsService).RegisterRoutes(router)
+	return router
+}
+
+func TestGetEventsForWeek_CalendarModeErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		expectedCode domain.ErrorCode
+	}{
+		{name: "Слишком большой limit", query: "&limit=100000", expectedCode: domain.CodeInvalidParameter},
+		{name: "Отрицательный limit", query: "&limit=-1", expectedCode: domain.CodeInvalidParameter},
+		{name: "Некорректный курсор", query: "&cursor=not-a-cursor", expectedCode: domain.CodeInvalidCursor},
+	}
+
+	router := newTestRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/events_for_week?user_id=1&date=2025-12-18&mode=calendar&week_start=sunday"+tt.query, nil)
+
+			require.NotPanics(t, func() { router.ServeHTTP(recorder, request) })
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+			var response domain.Response
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.Code)
+		})
+	}
+}
+
+func TestCreateEvent_RejectedConflictsInLocation(t *testing.T) {
+	router := newTestRouter()
+	createEvent := func(form url.Values) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/create_event", strings.NewReader(form.Encode()))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// Событие создано в UTC, пересекающееся с ним - в часовом поясе Москвы
+	require.Equal(t, http.StatusOK, createEvent(url.Values{"user_id": {"1"}, "date": {"2025-12-18T07:00"}, "text": {"Встреча"}, "tz": {"UTC"}}).Code)
+
+	recorder := createEvent(url.Values{"user_id": {"1"}, "date": {"2025-12-18T10:30"}, "text": {"Созвон"}, "tz": {"Europe/Moscow"}, "conflict_policy": {"reject"}})
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+
+	// Пересекающиеся события в ответе с ошибкой - в часовом поясе запроса, как и в успешном ответе
+	var response struct {
+		Code      domain.ErrorCode `json:"code"`
+		Conflicts []struct {
+			Date string `json:"date"`
+		} `json:"conflicts"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, domain.CodeEventConflict, response.Code)
+	require.Len(t, response.Conflicts, 1)
+	assert.Equal(t, "2025-12-18T10:00:00+03:00", response.Conflicts[0].Date)
+}
+
+func TestEnglishResponsesKeepDetails(t *testing.T) {
+	router := newTestRouter()
+	serve := func(method, target string, form url.Values) domain.Response {
+		request := httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		request = request.WithContext(i18n.WithLanguage(request.Context(), domain.LanguageEnglish))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		var response domain.Response
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		return response
+	}
+
+	// Позиция и лексема ошибки в фильтре
+	response := serve(http.MethodGet, "/events_for_day?user_id=1&date=2025-12-18&filter="+url.QueryEscape("id > abc"), nil)
+	assert.Equal(t, domain.CodeInvalidFilter, response.Code)
+	assert.Equal(t, `filter error at position 6: expected an integer, got "abc"`, response.Error)
+	require.Len(t, response.Details, 1)
+	assert.Equal(t, response.Error, response.Details[0].Message)
+
+	response = serve(http.MethodGet, "/events_for_day?user_id=1&date=2025-12-18&filter="+url.QueryEscape("title:a OR (id = 1"), nil)
+	assert.Equal(t, "filter error at position 19: unexpected end of filter", response.Error)
+
+	// Поле, с которым сравнивается дата окончания
+	response = serve(http.MethodPost, "/create_event", url.Values{"user_id": {"1"}, "date": {"2025-12-18T10:00"}, "end_date": {"2025-12-18T09:00"}, "text": {"Встреча"}})
+	assert.Equal(t, domain.CodeInvalidDate, response.Code)
+	assert.Equal(t, "parameter end_date must not be earlier than date", response.Error)
+	require.Len(t, response.Details, 1)
+	assert.Equal(t, "end_date", response.Details[0].Field)
+
+	// Сообщения об успехе тоже переводятся
+	response = serve(http.MethodPost, "/create_event", url.Values{"user_id": {"1"}, "date": {"2025-12-18T10:00"}, "text": {"Встреча"}})
+	require.Empty(t, response.Error)
+	response = serve(http.MethodPost, "/delete_event", url.Values{"user_id": {"1"}, "id": {"1"}})
+	assert.Equal(t, map[string]any{"message": "Event deleted successfully"}, response.Result)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"calendar/internal/domain"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// resolveLocation определяет часовой пояс запроса: параметр tz или предпочтение пользователя
+func (h *EventHandler) resolveLocation(r *http.Request, userID int) (*time.Location, error) {
+	loc, err := h.GetValidator().ParseLocation(r.FormValue("tz"))
+	if err != nil || loc != nil {
+		return loc, err
+	}
+
+	return h.settingsService.Location(userID)
+}
+
+// resolveWeekStart определяет первый день недели: параметр week_start или предпочтение пользователя
+func (h *EventHandler) resolveWeekStart(r *http.Request, userID int) (time.Weekday, error) {
+	weekStart, ok, err := h.GetValidator().ParseOptionalWeekStart(r.FormValue("week_start"))
+	if err != nil || ok {
+		return weekStart, err
+	}
+
+	return h.settingsService.WeekStart(userID)
+}
+
+// requestLocation определяет часовой пояс запроса, не привязанного к одному пользователю
+func (h *EventHandler) requestLocation(r *http.Request) (*time.Location, error) {
+	loc, err := h.GetValidator().ParseLocation(r.FormValue("tz"))
+	if err != nil || loc != nil {
+		return loc, err
+	}
+
+	return time.UTC, nil
+}
+
+// eventInLocation возвращает копию события со временем в часовом поясе loc
+func eventInLocation(event *domain.Event, loc *time.Location) *domain.Event {
+	if event == nil {
+		return nil
+	}
+
+	local := *event
+	local.Date = event.Date.In(loc)
+	if !event.EndDate.IsZero() {
+		local.EndDate = event.EndDate.In(loc)
+	}
+	local.CreatedAt = event.CreatedAt.In(loc)
+	local.UpdatedAt = event.UpdatedAt.In(loc)
+	return &local
+}
+
+// eventsInLocation возвращает копии событий со временем в часовом поясе loc
+func eventsInLocation(events []*domain.Event, loc *time.Location) []*domain.Event {
+	if events == nil {
+		return nil
+	}
+
+	local := make([]*domain.Event, len(events))
+	for i, event := range events {
+		local[i] = eventInLocation(event, loc)
+	}
+	return local
+}
+
+// conflictsInLocation переводит события из ошибки пересечения в часовой пояс loc, не изменяя саму ошибку;
+// другие ошибки возвращаются как есть
+func conflictsInLocation(err error, loc *time.Location) error {
+	var conflictErr *domain.ConflictError
+	if !errors.As(err, &conflictErr) {
+		return err
+	}
+	return &domain.ConflictError{AppError: conflictErr.AppError, Conflicts: eventsInLocation(conflictErr.Conflicts, loc)}
+}
+
+// pageInLocation возвращает страницу с копиями событий в часовом поясе loc
+func pageInLocation(page *domain.EventPage, loc *time.Location) *domain.EventPage {
+	return &domain.EventPage{
+		Events:     eventsInLocation(page.Events, loc),
+		NextCursor: page.NextCursor,
+	}
+}
+
+// freeBusyInLocation переводит интервалы занятости в часовой пояс loc
+func freeBusyInLocation(items []*domain.FreeBusy, loc *time.Location) {
+	for _, item := range items {
+		for i := range item.Busy {
+			item.Busy[i].Start = item.Busy[i].Start.In(loc)
+			item.Busy[i].End = item.Busy[i].End.In(loc)
+		}
+	}
+}
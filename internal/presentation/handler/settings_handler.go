@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"calendar/internal/application"
+	"calendar/internal/domain"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// SettingsHandler обрабатывает HTTP-запросы для настроек пользователей
+type SettingsHandler struct {
+	*BaseHandler
+	settingsService *application.UserSettingsService
+}
+
+// NewSettingsHandler создает новый экземпляр обработчика настроек
+func NewSettingsHandler(settingsService *application.UserSettingsService) *SettingsHandler {
+	return &SettingsHandler{
+		BaseHandler:     NewBaseHandler(),
+		settingsService: settingsService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты для настроек пользователей
+func (h *SettingsHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/user_settings", h.GetSettings).Methods("GET")
+	router.HandleFunc("/update_user_settings", h.UpdateSettings).Methods("POST")
+}
+
+// GetSettings возвращает настройки пользователя
+func (h *SettingsHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.GetValidator().ParseAndValidateUserID(r.URL.Query().Get("user_id"))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	settings, err := h.settingsService.GetSettings(userID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	h.writeSuccess(w, r, settings)
+}
+
+// UpdateSettings обновляет настройки пользователя
+func (h *SettingsHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	// Парсим и валидируем форму
+	fields, err := h.GetValidator().ParseFormAndValidate(r, []string{"user_id"})
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	userID, err := h.GetValidator().ParseAndValidateUserID(fields["user_id"])
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	patch := domain.UserSettings{
+		TimeZone:  r.FormValue("time_zone"),
+		WeekStart: r.FormValue("week_start"),
+		Language:  r.FormValue("language"),
+	}
+
+	settings, err := h.settingsService.UpdateSettings(userID, patch)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	h.writeSuccess(w, r, settings)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"calendar/internal/domain"
+	"fmt"
+)
+
+// StatusPolicy определяет, какими HTTP статус-кодами отвечать на ошибки бизнес-логики
+type StatusPolicy string
+
+const (
+	// StatusPolicyStrict - статус-коды REST: 404 - не найдено, 403 - нет прав,
+	// 409 - конфликт, 422 - нарушение бизнес-правила
+	StatusPolicyStrict StatusPolicy = "strict"
+	// StatusPolicyLegacy - режим совместимости: на ненайденный объект, отсутствие прав
+	// и нарушение бизнес-правила отвечать 503, как в ранних версиях API
+	StatusPolicyLegacy StatusPolicy = "legacy"
+)
+
+// ParseStatusPolicy парсит политику статус-кодов; пустое значение дает StatusPolicyStrict
+func ParseStatusPolicy(value string) (StatusPolicy, error) {
+	switch policy := StatusPolicy(value); policy {
+	case "":
+		return StatusPolicyStrict, nil
+	case StatusPolicyStrict, StatusPolicyLegacy:
+		return policy, nil
+	}
+	return "", fmt.Errorf("неизвестная политика статус-кодов %q, допустимы %s и %s", value, StatusPolicyStrict, StatusPolicyLegacy)
+}
+
+// Status возвращает HTTP статус-код ответа на ошибку с исходным статус-кодом statusCode и кодом code
+func (p StatusPolicy) Status(statusCode int, code domain.ErrorCode) int {
+	if p != StatusPolicyLegacy {
+		return statusCode
+	}
+
+	if code == domain.CodeBusinessLogic || statusCode == domain.StatusNotFound || statusCode == domain.StatusForbidden {
+		return domain.StatusServiceUnavailable
+	}
+	return statusCode
+}
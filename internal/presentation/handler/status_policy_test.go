@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatusPolicy(t *testing.T) {
+	policy, err := ParseStatusPolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusPolicyStrict, policy)
+
+	policy, err = ParseStatusPolicy("legacy")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusPolicyLegacy, policy)
+
+	_, err = ParseStatusPolicy("lenient")
+	assert.Error(t, err)
+}
+
+func TestHandleError_StatusPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStrict int
+		expectedLegacy int
+		expectedCode   domain.ErrorCode
+	}{
+		{
+			name:           "Событие не найдено",
+			err:            domain.NewNotFoundError("событие не найдено").WithCode(domain.CodeEventNotFound),
+			expectedStrict: http.StatusNotFound,
+			expectedLegacy: http.StatusServiceUnavailable,
+			expectedCode:   domain.CodeEventNotFound,
+		},
+		{
+			name:           "Нет прав",
+			err:            domain.NewAccessDeniedError("нет прав для удаления этого события"),
+			expectedStrict: http.StatusForbidden,
+			expectedLegacy: http.StatusServiceUnavailable,
+			expectedCode:   domain.CodeAccessDenied,
+		},
+		{
+			name:           "Нарушение бизнес-правила",
+			err:            domain.NewBusinessLogicError("операция невозможна"),
+			expectedStrict: http.StatusUnprocessableEntity,
+			expectedLegacy: http.StatusServiceUnavailable,
+			expectedCode:   domain.CodeBusinessLogic,
+		},
+		{
+			name:           "Обернутая ошибка приложения",
+			err:            fmt.Errorf("удаление: %w", domain.NewNotFoundError("событие не найдено").WithCode(domain.CodeEventNotFound)),
+			expectedStrict: http.StatusNotFound,
+			expectedLegacy: http.StatusServiceUnavailable,
+			expectedCode:   domain.CodeEventNotFound,
+		},
+		{
+			name:           "Пересечение событий",
+			err:            domain.NewConflictError("событие пересекается с существующими событиями", nil),
+			expectedStrict: http.StatusConflict,
+			expectedLegacy: http.StatusConflict,
+			expectedCode:   domain.CodeEventConflict,
+		},
+		{
+			name:           "Ошибка валидации",
+			err:            domain.NewFieldError("date", domain.CodeInvalidDate, "некорректный формат даты"),
+			expectedStrict: http.StatusBadRequest,
+			expectedLegacy: http.StatusBadRequest,
+			expectedCode:   domain.CodeInvalidDate,
+		},
+		{
+			name:           "Внутренняя ошибка",
+			err:            domain.NewInternalError("ошибка при удалении события", errors.New("диск недоступен")),
+			expectedStrict: http.StatusInternalServerError,
+			expectedLegacy: http.StatusInternalServerError,
+			expectedCode:   domain.CodeInternal,
+		},
+		{
+			name:           "Неизвестная ошибка",
+			err:            errors.New("неизвестная ошибка"),
+			expectedStrict: http.StatusInternalServerError,
+			expectedLegacy: http.StatusInternalServerError,
+			expectedCode:   domain.CodeInternal,
+		},
+		{
+			name:           "Запрос отменен",
+			err:            context.Canceled,
+			expectedStrict: http.StatusServiceUnavailable,
+			expectedLegacy: http.StatusServiceUnavailable,
+			expectedCode:   domain.CodeRequestCanceled,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, mode := range []struct {
+			policy   StatusPolicy
+			expected int
+		}{
+			{StatusPolicyStrict, tt.expectedStrict},
+			{StatusPolicyLegacy, tt.expectedLegacy},
+		} {
+			t.Run(tt.name+"/"+string(mode.policy), func(t *testing.T) {
+				h := NewBaseHandler()
+				h.SetStatusPolicy(mode.policy)
+
+				var observed *domain.AppError
+				h.ObserveErrors(func(err *domain.AppError) { observed = err })
+
+				recorder := httptest.NewRecorder()
+				h.handleError(recorder, httptest.NewRequest(http.MethodPost, "/delete_event", nil), tt.err)
+
+				assert.Equal(t, mode.expected, recorder.Code)
+
+				var response domain.Response
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedCode, response.Code)
+				assert.NotEmpty(t, response.Error)
+
+				// Политика меняет только ответ; исходная ошибка и метрики видят статус-код приложения
+				var appErr *domain.AppError
+				if errors.As(tt.err, &appErr) {
+					require.NotNil(t, observed)
+					assert.Equal(t, tt.expectedStrict, observed.GetStatusCode())
+					assert.Equal(t, tt.expectedStrict, appErr.GetStatusCode())
+				}
+			})
+		}
+	}
+}
+
+func TestStatusPolicy_BatchItems(t *testing.T) {
+	tests := []struct {
+		status   int
+		code     domain.ErrorCode
+		expected map[StatusPolicy]int
+	}{
+		{domain.StatusOK, "", map[StatusPolicy]int{StatusPolicyStrict: 200, StatusPolicyLegacy: 200}},
+		{domain.StatusNotFound, domain.CodeEventNotFound, map[StatusPolicy]int{StatusPolicyStrict: 404, StatusPolicyLegacy: 503}},
+		{domain.StatusForbidden, domain.CodeAccessDenied, map[StatusPolicy]int{StatusPolicyStrict: 403, StatusPolicyLegacy: 503}},
+		{domain.StatusBadRequest, domain.CodeTagNotFound, map[StatusPolicy]int{StatusPolicyStrict: 400, StatusPolicyLegacy: 400}},
+		{domain.StatusFailedDependency, domain.CodeFailedDependency, map[StatusPolicy]int{StatusPolicyStrict: 424, StatusPolicyLegacy: 424}},
+	}
+
+	for _, tt := range tests {
+		for policy, expected := range tt.expected {
+			assert.Equal(t, expected, policy.Status(tt.status, tt.code), "%s %d %s", policy, tt.status, tt.code)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"calendar/internal/application"
+	"calendar/internal/infrastructure/i18n"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// TagHandler обрабатывает HTTP-запросы для тегов
+type TagHandler struct {
+	*BaseHandler
+	tagService *application.TagService
+}
+
+// NewTagHandler создает новый экземпляр обработчика тегов
+func NewTagHandler(tagService *application.TagService) *TagHandler {
+	return &TagHandler{
+		BaseHandler: NewBaseHandler(),
+		tagService:  tagService,
+	}
+}
+
+// RegisterRoutes регистрирует маршруты для тегов
+func (h *TagHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/tags", h.GetTags).Methods("GET")
+	router.HandleFunc("/create_tag", h.CreateTag).Methods("POST")
+	router.HandleFunc("/update_tag", h.UpdateTag).Methods("POST")
+	router.HandleFunc("/delete_tag", h.DeleteTag).Methods("POST")
+}
+
+// GetTags возвращает теги пользователя
+func (h *TagHandler) GetTags(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.GetValidator().ParseAndValidateUserID(r.URL.Query().Get("user_id"))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	tags, err := h.tagService.GetTags(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	h.writeSuccess(w, r, tags)
+}
+
+// CreateTag создает новый тег
+func (h *TagHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	// Парсим и валидируем форму
+	fields, err := h.GetValidator().ParseFormAndValidate(r, []string{"user_id", "name"})
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	userID, err := h.GetValidator().ParseAndValidateUserID(fields["user_id"])
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	tag, err := h.tagService.CreateTag(r.Context(), userID, fields["name"], r.FormValue("color"))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	h.writeSuccess(w, r, tag)
+}
+
+// UpdateTag переименовывает тег и/или меняет его цвет
+func (h *TagHandler) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	// Парсим и валидируем форму
+	fields, err := h.GetValidator().ParseFormAndValidate(r, []string{"id", "user_id"})
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	id, err := h.GetValidator().ParseAndValidateID(fields["id"])
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	userID, err := h.GetValidator().ParseAndValidateUserID(fields["user_id"])
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	tag, err := h.tagService.UpdateTag(r.Context(), id, userID, r.FormValue("name"), r.FormValue("color"))
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	h.writeSuccess(w, r, tag)
+}
+
+// DeleteTag удаляет тег и снимает его со всех событий
+func (h *TagHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	// Парсим и валидируем форму
+	fields, err := h.GetValidator().ParseFormAndValidate(r, []string{"id", "user_id"})
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	id, err := h.GetValidator().ParseAndValidateID(fields["id"])
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	userID, err := h.GetValidator().ParseAndValidateUserID(fields["user_id"])
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	if err := h.tagService.DeleteTag(r.Context(), id, userID); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	h.writeSuccess(w, r, map[string]string{"message": i18n.NoticeMessage(i18n.Language(r.Context()), i18n.NoticeTagDeleted)})
+}
@@ -1,12 +1,34 @@
 package handler
 
 import (
+	"calendar/internal/application/filter"
 	"calendar/internal/domain"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// MaxJSONBodySize - максимальный размер JSON-тела запроса в байтах
+const MaxJSONBodySize = 4 << 20
+
+// isoWeekPattern - формат идентификатора недели ISO 8601, например 2025-W51
+var isoWeekPattern = regexp.MustCompile(`^(\d{4})-W(\d{2})$`)
+
+// dateTimeLayouts - допустимые форматы даты со временем, от более точного к менее точному
+var dateTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
 // RequestValidator содержит методы для валидации HTTP-запросов
 type RequestValidator struct{}
 
@@ -18,16 +40,16 @@ func NewRequestValidator() *RequestValidator {
 // ParseAndValidateUserID парсит и валидирует user_id из формы или query
 func (v *RequestValidator) ParseAndValidateUserID(value string) (int, error) {
 	if value == "" {
-		return 0, domain.NewValidationError("параметр user_id обязателен")
+		return 0, domain.NewFieldError("user_id", domain.CodeMissingParameter, "параметр user_id обязателен")
 	}
 
 	userID, err := strconv.Atoi(value)
 	if err != nil {
-		return 0, domain.NewValidationError("некорректный user_id")
+		return 0, domain.NewFieldError("user_id", domain.CodeInvalidParameter, "некорректный user_id")
 	}
 
 	if userID <= 0 {
-		return 0, domain.NewValidationError("user_id должен быть положительным числом")
+		return 0, domain.NewFieldError("user_id", domain.CodeInvalidParameter, "user_id должен быть положительным числом")
 	}
 
 	return userID, nil
@@ -36,54 +58,377 @@ func (v *RequestValidator) ParseAndValidateUserID(value string) (int, error) {
 // ParseAndValidateID парсит и валидирует id из формы
 func (v *RequestValidator) ParseAndValidateID(value string) (int, error) {
 	if value == "" {
-		return 0, domain.NewValidationError("параметр id обязателен")
+		return 0, domain.NewFieldError("id", domain.CodeMissingParameter, "параметр id обязателен")
 	}
 
 	id, err := strconv.Atoi(value)
 	if err != nil {
-		return 0, domain.NewValidationError("некорректный id")
+		return 0, domain.NewFieldError("id", domain.CodeInvalidParameter, "некорректный id")
 	}
 
 	if id <= 0 {
-		return 0, domain.NewValidationError("id должен быть положительным числом")
+		return 0, domain.NewFieldError("id", domain.CodeInvalidParameter, "id должен быть положительным числом")
 	}
 
 	return id, nil
 }
 
-// ParseAndValidateDate парсит и валидирует дату из строки
-func (v *RequestValidator) ParseAndValidateDate(value string) (time.Time, error) {
+// ParseAndValidateDate парсит и валидирует дату из строки в часовом поясе loc
+func (v *RequestValidator) ParseAndValidateDate(value string, loc *time.Location) (time.Time, error) {
 	if value == "" {
-		return time.Time{}, domain.NewValidationError("параметр date обязателен")
+		return time.Time{}, domain.NewFieldError("date", domain.CodeMissingParameter, "параметр date обязателен")
 	}
 
-	date, err := time.Parse("2006-01-02", value)
+	date, err := time.ParseInLocation("2006-01-02", value, loc)
 	if err != nil {
-		return time.Time{}, domain.NewValidationError("некорректный формат даты, используйте YYYY-MM-DD")
+		return time.Time{}, domain.NewFieldError("date", domain.CodeInvalidDate, "некорректный формат даты, используйте YYYY-MM-DD")
 	}
 
 	return date, nil
 }
 
-// ParseAndValidateYearMonth парсит и валидирует год и месяц из строки
-func (v *RequestValidator) ParseAndValidateYearMonth(value string) (time.Time, error) {
+// ParseAndValidateDateTime парсит и валидирует дату или дату со временем из параметра name.
+// Значения без смещения интерпретируются в часовом поясе loc.
+func (v *RequestValidator) ParseAndValidateDateTime(name, value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, domain.NewFieldError(name, domain.CodeMissingParameter, "параметр "+name+" обязателен")
+	}
+
+	for _, layout := range dateTimeLayouts {
+		if date, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, domain.NewFieldError(name, domain.CodeInvalidDate, "некорректный формат параметра "+name+", используйте YYYY-MM-DD или YYYY-MM-DDTHH:MM")
+}
+
+// ParseOptionalDateTime парсит необязательную дату со временем; пустое значение дает нулевое время
+func (v *RequestValidator) ParseOptionalDateTime(name, value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return v.ParseAndValidateDateTime(name, value, loc)
+}
+
+// ParseLocation парсит необязательный часовой пояс IANA; пустое значение дает nil
+func (v *RequestValidator) ParseLocation(value string) (*time.Location, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	loc, err := time.LoadLocation(value)
+	if err != nil {
+		return nil, domain.NewFieldError("tz", domain.CodeInvalidParameter, "неизвестный часовой пояс: "+value).
+			WithDetails(domain.MsgUnknownTimeZone, domain.MessageParams{"value": value})
+	}
+
+	return loc, nil
+}
+
+// propertyPrefix - префикс параметров формы с пользовательскими свойствами события
+const propertyPrefix = "prop."
+
+// ParseEventLocation парсит место проведения события из текста и необязательных координат lat и lon.
+// Координаты задаются только парой; если не задано ничего, возвращается nil.
+func (v *RequestValidator) ParseEventLocation(text, lat, lon string) (*domain.Location, error) {
+	text = strings.TrimSpace(text)
+	if lat == "" && lon == "" {
+		if text == "" {
+			return nil, nil
+		}
+		return &domain.Location{Text: text}, nil
+	}
+	if lat == "" || lon == "" {
+		return nil, domain.NewFieldError("lat", domain.CodeInvalidParameter, "параметры lat и lon задаются вместе").WithField("lon")
+	}
+
+	// ParseFloat принимает NaN и Inf, которые координатами не являются
+	latitude, err := strconv.ParseFloat(lat, 64)
+	if err != nil || math.IsNaN(latitude) || math.IsInf(latitude, 0) {
+		return nil, domain.NewFieldError("lat", domain.CodeInvalidParameter, "некорректный параметр lat")
+	}
+	longitude, err := strconv.ParseFloat(lon, 64)
+	if err != nil || math.IsNaN(longitude) || math.IsInf(longitude, 0) {
+		return nil, domain.NewFieldError("lon", domain.CodeInvalidParameter, "некорректный параметр lon")
+	}
+
+	return &domain.Location{
+		Text: text,
+		Geo:  &domain.GeoPoint{Lat: latitude, Lon: longitude},
+	}, nil
+}
+
+// ParseEventProperties собирает пользовательские свойства события из параметров вида prop.<ключ>=<значение>
+func (v *RequestValidator) ParseEventProperties(form url.Values) (map[string]string, error) {
+	var properties map[string]string
+	for name, values := range form {
+		key, ok := strings.CutPrefix(name, propertyPrefix)
+		if !ok {
+			continue
+		}
+		if key == "" {
+			return nil, domain.NewFieldError(propertyPrefix, domain.CodeInvalidParameter, "не указан ключ пользовательского свойства")
+		}
+		if len(values) > 1 {
+			return nil, domain.NewFieldError(propertyPrefix+key, domain.CodeInvalidParameter, "свойство "+key+" указано несколько раз")
+		}
+		if properties == nil {
+			properties = make(map[string]string)
+		}
+		properties[key] = values[0]
+	}
+	return properties, nil
+}
+
+// ParseAndValidateUserIDs парсит и валидирует список user_id, разделенных запятыми, из параметра name
+func (v *RequestValidator) ParseAndValidateUserIDs(name, value string) ([]int, error) {
+	if value == "" {
+		return nil, domain.NewFieldError(name, domain.CodeMissingParameter, "параметр "+name+" обязателен")
+	}
+
+	parts := strings.Split(value, ",")
+	userIDs := make([]int, 0, len(parts))
+	seen := make(map[int]bool, len(parts))
+	for _, part := range parts {
+		userID, err := v.ParseAndValidateUserID(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		if !seen[userID] {
+			seen[userID] = true
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	return userIDs, nil
+}
+
+// ParseAndValidateDuration парсит и валидирует длительность вида 30m или 1h30m из параметра name
+func (v *RequestValidator) ParseAndValidateDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, domain.NewFieldError(name, domain.CodeMissingParameter, "параметр "+name+" обязателен")
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, domain.NewFieldError(name, domain.CodeInvalidParameter, "некорректный формат параметра "+name+", используйте, например, 30m или 1h30m")
+	}
+
+	return duration, nil
+}
+
+// ParseOptionalDuration парсит необязательную длительность, подставляя значение по умолчанию
+func (v *RequestValidator) ParseOptionalDuration(name, value string, def time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+	return v.ParseAndValidateDuration(name, value)
+}
+
+// ParseOptionalClock парсит необязательное время суток HH:MM и возвращает смещение от полуночи
+func (v *RequestValidator) ParseOptionalClock(name, value string, def time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		if value == "24:00" {
+			return 24 * time.Hour, nil
+		}
+		return 0, domain.NewFieldError(name, domain.CodeInvalidParameter, "некорректный формат параметра "+name+", используйте HH:MM")
+	}
+
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+// ParseOptionalInt парсит необязательное целое число, подставляя значение по умолчанию
+func (v *RequestValidator) ParseOptionalInt(name, value string, def int) (int, error) {
 	if value == "" {
-		return time.Time{}, domain.NewValidationError("параметр date обязателен")
+		return def, nil
 	}
 
-	yearMonth, err := time.Parse("2006-01", value)
+	n, err := strconv.Atoi(value)
 	if err != nil {
-		return time.Time{}, domain.NewValidationError("некорректный формат даты, используйте YYYY-MM")
+		return 0, domain.NewFieldError(name, domain.CodeInvalidParameter, "некорректный параметр "+name)
+	}
+
+	return n, nil
+}
+
+// ParseOptionalBool парсит необязательный логический параметр
+func (v *RequestValidator) ParseOptionalBool(name, value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, domain.NewFieldError(name, domain.CodeInvalidParameter, "некорректный параметр "+name)
+	}
+
+	return b, nil
+}
+
+// ParseConflictPolicy парсит политику пересечений; по умолчанию пересечения возвращаются как предупреждение
+func (v *RequestValidator) ParseConflictPolicy(value string) (domain.ConflictPolicy, error) {
+	switch policy := domain.ConflictPolicy(value); policy {
+	case "":
+		return domain.ConflictWarn, nil
+	case domain.ConflictAllow, domain.ConflictWarn, domain.ConflictReject:
+		return policy, nil
+	}
+	return "", domain.NewFieldError("conflict_policy", domain.CodeInvalidParameter, "некорректный conflict_policy, используйте allow, warn или reject")
+}
+
+// ParseISOWeek парсит идентификатор недели ISO 8601 и возвращает полночь ее понедельника в поясе loc.
+// Второе значение сообщает, похожа ли строка на идентификатор недели.
+func (v *RequestValidator) ParseISOWeek(value string, loc *time.Location) (time.Time, bool, error) {
+	match := isoWeekPattern.FindStringSubmatch(value)
+	if match == nil {
+		return time.Time{}, false, nil
+	}
+
+	year, _ := strconv.Atoi(match[1])
+	week, _ := strconv.Atoi(match[2])
+
+	// 4 января всегда попадает в первую неделю ISO-года
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	monday := jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7+(week-1)*7)
+
+	if isoYear, isoWeek := monday.ISOWeek(); week < 1 || isoYear != year || isoWeek != week {
+		return time.Time{}, true, domain.NewFieldError("date", domain.CodeInvalidDate, "несуществующая неделя "+value)
+	}
+
+	return monday, true, nil
+}
+
+// ParseWeekMode парсит режим недельной выборки: rolling (7 дней от даты) или calendar (календарная неделя)
+func (v *RequestValidator) ParseWeekMode(value string) (string, error) {
+	switch value {
+	case "", weekModeRolling:
+		return weekModeRolling, nil
+	case weekModeCalendar:
+		return weekModeCalendar, nil
+	}
+	return "", domain.NewFieldError("mode", domain.CodeInvalidParameter, "некорректный параметр mode, используйте rolling или calendar")
+}
+
+// ParseOptionalWeekStart парсит необязательный первый день недели; пустое значение дает false
+func (v *RequestValidator) ParseOptionalWeekStart(value string) (time.Weekday, bool, error) {
+	if value == "" {
+		return 0, false, nil
+	}
+
+	weekday, ok := domain.ParseWeekStart(value)
+	if !ok {
+		return 0, false, domain.NewFieldError("week_start", domain.CodeInvalidParameter, "некорректный параметр week_start, используйте monday, sunday или saturday")
+	}
+
+	return weekday, true, nil
+}
+
+// ParseAndValidateYearMonth парсит и валидирует год и месяц из строки в часовом поясе loc
+func (v *RequestValidator) ParseAndValidateYearMonth(value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, domain.NewFieldError("date", domain.CodeMissingParameter, "параметр date обязателен")
+	}
+
+	yearMonth, err := time.ParseInLocation("2006-01", value, loc)
+	if err != nil {
+		return time.Time{}, domain.NewFieldError("date", domain.CodeInvalidDate, "некорректный формат даты, используйте YYYY-MM")
 	}
 
 	return yearMonth, nil
 }
 
+// ParseTags разбирает список имен тегов, разделенных запятыми; пустая строка означает отсутствие тегов
+func (v *RequestValidator) ParseTags(value string) []string {
+	tags := []string{}
+	for _, part := range strings.Split(value, ",") {
+		if name := strings.TrimSpace(part); name != "" {
+			tags = append(tags, name)
+		}
+	}
+	return tags
+}
+
+// ParsePageRequest парсит параметры сортировки, фильтрации и постраничной выборки sort, filter, tag, limit и cursor.
+// Даты в фильтре интерпретируются в часовом поясе loc. Параметр tag отбирает события,
+// отмеченные хотя бы одним из перечисленных тегов, и объединяется с filter по AND.
+func (v *RequestValidator) ParsePageRequest(r *http.Request, loc *time.Location) (domain.PageRequest, error) {
+	page := domain.PageRequest{
+		Sort:   domain.EventSort(r.FormValue("sort")),
+		Cursor: r.FormValue("cursor"),
+	}
+
+	switch page.Sort {
+	case "":
+		page.Sort = domain.SortByDate
+	case domain.SortByDate, domain.SortByUpdatedAt:
+	default:
+		return page, domain.NewFieldError("sort", domain.CodeInvalidParameter, "некорректный параметр sort, используйте date или updated_at")
+	}
+
+	limit, err := v.ParseOptionalInt("limit", r.FormValue("limit"), 0)
+	if err != nil {
+		return page, err
+	}
+	page.Limit = limit
+
+	if expr := r.FormValue("filter"); expr != "" {
+		f, err := filter.Parse(expr, loc)
+		if err != nil {
+			return page, err
+		}
+		page.Filter = f
+	}
+
+	if tags := v.ParseTags(r.FormValue("tag")); len(tags) > 0 {
+		page.Filter = filter.All(page.Filter, filter.HasTag(tags...))
+	}
+
+	return page, nil
+}
+
+// ParseAndValidateYear парсит и валидирует год из строки в часовом поясе loc
+func (v *RequestValidator) ParseAndValidateYear(value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, domain.NewFieldError("date", domain.CodeMissingParameter, "параметр date обязателен")
+	}
+
+	year, err := time.ParseInLocation("2006", value, loc)
+	if err != nil {
+		return time.Time{}, domain.NewFieldError("date", domain.CodeInvalidDate, "некорректный формат даты, используйте YYYY")
+	}
+
+	return year, nil
+}
+
+// DecodeJSONBody разбирает JSON-тело запроса размером не больше MaxJSONBodySize в dst
+func (v *RequestValidator) DecodeJSONBody(r *http.Request, dst interface{}) error {
+	if mediaType := r.Header.Get("Content-Type"); !strings.HasPrefix(mediaType, "application/json") {
+		return domain.NewValidationError("тело запроса должно быть в формате application/json").WithCode(domain.CodeInvalidJSON)
+	}
+
+	decoder := json.NewDecoder(io.LimitReader(r.Body, MaxJSONBodySize+1))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return domain.NewValidationError("тело запроса обрезано или превышает допустимый размер").WithCode(domain.CodeInvalidJSON)
+		}
+		return domain.NewValidationError("некорректный JSON в теле запроса: " + err.Error()).WithCode(domain.CodeInvalidJSON)
+	}
+
+	return nil
+}
+
 // ValidateRequiredFields проверяет, что все обязательные поля присутствуют
 func (v *RequestValidator) ValidateRequiredFields(fields map[string]string) error {
 	for name, value := range fields {
 		if value == "" {
-			return domain.NewValidationError("параметр " + name + " обязателен")
+			return domain.NewFieldError(name, domain.CodeMissingParameter, "параметр "+name+" обязателен")
 		}
 	}
 	return nil
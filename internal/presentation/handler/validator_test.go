@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"calendar/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEventLocation(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		lat, lon      string
+		expected      *domain.Location
+		expectedField string
+	}{
+		{name: "Без места проведения", expected: nil},
+		{name: "Только текст", text: " Переговорная ", expected: &domain.Location{Text: "Переговорная"}},
+		{name: "Координаты", text: "Офис", lat: "55.75", lon: "37.62", expected: &domain.Location{Text: "Офис", Geo: &domain.GeoPoint{Lat: 55.75, Lon: 37.62}}},
+		{name: "Только широта", lat: "55.75", expectedField: "lat"},
+		{name: "Некорректная широта", lat: "север", lon: "37.62", expectedField: "lat"},
+		{name: "Широта NaN", lat: "NaN", lon: "37.62", expectedField: "lat"},
+		{name: "Бесконечная широта", lat: "-Inf", lon: "37.62", expectedField: "lat"},
+		{name: "Долгота NaN", lat: "55.75", lon: "nan", expectedField: "lon"},
+		{name: "Бесконечная долгота", lat: "55.75", lon: "+Infinity", expectedField: "lon"},
+	}
+
+	v := NewRequestValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := v.ParseEventLocation(tt.text, tt.lat, tt.lon)
+			if tt.expectedField == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, location)
+				return
+			}
+
+			var appErr *domain.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, domain.CodeInvalidParameter, appErr.ErrorCode())
+			require.NotEmpty(t, appErr.Fields)
+			assert.Equal(t, tt.expectedField, appErr.Fields[0].Field)
+			assert.Nil(t, location)
+		})
+	}
+}
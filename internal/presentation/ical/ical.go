@@ -0,0 +1,156 @@
+package ical
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"calendar/internal/domain"
+)
+
+const (
+	// ContentType - MIME-тип iCalendar
+	ContentType = "text/calendar; charset=utf-8"
+
+	prodID     = "-//calendar//calendar service//RU"
+	timeLayout = "20060102T150405Z"
+
+	// maxLineLength - максимальная длина строки содержимого в октетах до переноса
+	maxLineLength = 75
+)
+
+// textEscaper экранирует значения типа TEXT
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// Writer записывает объекты iCalendar (RFC 5545) с переводами строк CRLF
+type Writer struct {
+	b strings.Builder
+}
+
+// line добавляет строку содержимого, перенося ее по границе в 75 октетов
+func (w *Writer) line(format string, args ...interface{}) {
+	content := fmt.Sprintf(format, args...)
+	// Строки продолжения начинаются с пробела, который входит в лимит
+	for limit := maxLineLength; len(content) > limit; limit = maxLineLength - 1 {
+		cut := limit
+		// Не разрываем многобайтовые символы UTF-8
+		for cut > 0 && content[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.b.WriteString(content[:cut])
+		w.b.WriteString("\r\n ")
+		content = content[cut:]
+	}
+	w.b.WriteString(content)
+	w.b.WriteString("\r\n")
+}
+
+// text добавляет свойство типа TEXT, если значение не пустое
+func (w *Writer) text(name, value string) {
+	if value != "" {
+		w.line("%s:%s", name, textEscaper.Replace(value))
+	}
+}
+
+// propertyName преобразует ключ пользовательского свойства в имя X-свойства iCalendar
+func propertyName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '-'
+	}, key)
+	return "X-CALENDAR-" + name
+}
+
+// formatTime форматирует время в UTC в формате iCalendar
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// WriteFreeBusy записывает занятость пользователей как набор компонентов VFREEBUSY
+func WriteFreeBusy(out io.Writer, from, to time.Time, items []*domain.FreeBusy) error {
+	w := &Writer{}
+	stamp := formatTime(time.Now())
+
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:%s", prodID)
+	w.line("METHOD:PUBLISH")
+	for _, item := range items {
+		w.line("BEGIN:VFREEBUSY")
+		w.line("UID:freebusy-%d-%s-%s", item.UserID, formatTime(from), formatTime(to))
+		w.line("DTSTAMP:%s", stamp)
+		w.line("ATTENDEE:urn:calendar:user:%d", item.UserID)
+		w.line("DTSTART:%s", formatTime(from))
+		w.line("DTEND:%s", formatTime(to))
+		for _, period := range item.Busy {
+			w.line("FREEBUSY;FBTYPE=BUSY:%s/%s", formatTime(period.Start), formatTime(period.End))
+		}
+		w.line("END:VFREEBUSY")
+	}
+	w.line("END:VCALENDAR")
+
+	_, err := io.WriteString(out, w.b.String())
+	return err
+}
+
+// WriteEvents записывает события как набор компонентов VEVENT.
+// Заголовок события становится SUMMARY, а текст при наличии заголовка - COMMENT.
+func WriteEvents(out io.Writer, events []*domain.Event) error {
+	w := &Writer{}
+
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:%s", prodID)
+	w.line("METHOD:PUBLISH")
+	for _, event := range events {
+		w.line("BEGIN:VEVENT")
+		w.line("UID:event-%d@calendar", event.ID)
+		w.line("DTSTAMP:%s", formatTime(event.UpdatedAt))
+		w.line("DTSTART:%s", formatTime(event.Date))
+		w.line("DTEND:%s", formatTime(event.End()))
+		if !event.CreatedAt.IsZero() {
+			w.line("CREATED:%s", formatTime(event.CreatedAt))
+			w.line("LAST-MODIFIED:%s", formatTime(event.UpdatedAt))
+		}
+		w.text("SUMMARY", event.Summary())
+		if event.Title != "" {
+			w.text("COMMENT", event.Text)
+		}
+		w.text("DESCRIPTION", event.Description)
+		w.text("LOCATION", event.LocationText())
+		if event.Location != nil && event.Location.Geo != nil {
+			w.line("GEO:%f;%f", event.Location.Geo.Lat, event.Location.Geo.Lon)
+		}
+		if event.URL != "" {
+			w.line("URL:%s", event.URL)
+		}
+		if len(event.Tags) > 0 {
+			categories := make([]string, len(event.Tags))
+			for i, tag := range event.Tags {
+				categories[i] = textEscaper.Replace(tag)
+			}
+			w.line("CATEGORIES:%s", strings.Join(categories, ","))
+		}
+
+		keys := make([]string, 0, len(event.Properties))
+		for key := range event.Properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			w.text(propertyName(key), event.Properties[key])
+		}
+		w.line("END:VEVENT")
+	}
+	w.line("END:VCALENDAR")
+
+	_, err := io.WriteString(out, w.b.String())
+	return err
+}
@@ -0,0 +1,109 @@
+package ical
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingWriter возвращает ошибку на любую запись
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("соединение закрыто")
+}
+
+func TestWriteFreeBusy(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+	items := []*domain.FreeBusy{
+		{
+			UserID: 1,
+			Busy: []domain.TimePeriod{
+				{Start: time.Date(2024, 1, 15, 12, 0, 0, 0, moscow), End: time.Date(2024, 1, 15, 13, 30, 0, 0, moscow)},
+				{Start: time.Date(2024, 1, 15, 15, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 15, 16, 0, 0, 0, time.UTC)},
+			},
+		},
+		{UserID: 2},
+	}
+
+	var out strings.Builder
+	require.NoError(t, WriteFreeBusy(&out, from, to, items))
+
+	// Все строки завершаются CRLF
+	body := out.String()
+	require.True(t, strings.HasSuffix(body, "\r\n"))
+	lines := strings.Split(strings.TrimSuffix(body, "\r\n"), "\r\n")
+	for _, line := range lines {
+		assert.NotContains(t, line, "\n")
+	}
+
+	// DTSTAMP зависит от текущего времени, остальное выводится детерминированно
+	var stamps int
+	var rest []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "DTSTAMP:") {
+			stamps++
+			continue
+		}
+		rest = append(rest, line)
+	}
+	assert.Equal(t, 2, stamps)
+	assert.Equal(t, []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//calendar//calendar service//RU",
+		"METHOD:PUBLISH",
+		"BEGIN:VFREEBUSY",
+		"UID:freebusy-1-20240115T000000Z-20240116T000000Z",
+		"ATTENDEE:urn:calendar:user:1",
+		"DTSTART:20240115T000000Z",
+		"DTEND:20240116T000000Z",
+		// Время переводится в UTC
+		"FREEBUSY;FBTYPE=BUSY:20240115T090000Z/20240115T103000Z",
+		"FREEBUSY;FBTYPE=BUSY:20240115T150000Z/20240115T160000Z",
+		"END:VFREEBUSY",
+		"BEGIN:VFREEBUSY",
+		"UID:freebusy-2-20240115T000000Z-20240116T000000Z",
+		"ATTENDEE:urn:calendar:user:2",
+		"DTSTART:20240115T000000Z",
+		"DTEND:20240116T000000Z",
+		"END:VFREEBUSY",
+		"END:VCALENDAR",
+	}, rest)
+}
+
+func TestWriteFreeBusy_Empty(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, WriteFreeBusy(&out, time.Now(), time.Now().Add(time.Hour), nil))
+
+	assert.Equal(t, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//calendar//calendar service//RU\r\nMETHOD:PUBLISH\r\nEND:VCALENDAR\r\n", out.String())
+}
+
+func TestWriteFreeBusy_WriteError(t *testing.T) {
+	err := WriteFreeBusy(failingWriter{}, time.Now(), time.Now().Add(time.Hour), []*domain.FreeBusy{{UserID: 1}})
+	assert.EqualError(t, err, "соединение закрыто")
+}
+
+func TestWriter_LineFolding(t *testing.T) {
+	w := &Writer{}
+	w.text("SUMMARY", strings.Repeat("я", 60))
+
+	lines := strings.Split(strings.TrimSuffix(w.b.String(), "\r\n"), "\r\n")
+	require.Len(t, lines, 2)
+	for i, line := range lines {
+		assert.LessOrEqual(t, len(line), maxLineLength)
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(line, " "))
+		}
+	}
+	// Перенос не разрывает символы
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("я", 60), lines[0]+strings.TrimPrefix(lines[1], " "))
+}
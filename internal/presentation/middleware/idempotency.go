@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"bytes"
+	"calendar/internal/domain"
+	"calendar/internal/presentation/problem"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// IdempotencyKeyHeader - заголовок с ключом идемпотентности запроса
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader - заголовок, которым помечается повторно отданный ответ
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	// MaxIdempotencyKeyLength - максимальная длина ключа идемпотентности
+	MaxIdempotencyKeyLength = 255
+	// MaxIdempotentBodySize - максимальный размер тела запроса с ключом идемпотентности
+	MaxIdempotentBodySize = 4 << 20
+)
+
+// IdempotencyMiddleware сохраняет первый ответ на изменяющий запрос с заголовком Idempotency-Key
+// и отдает его же на повторы с тем же ключом. Ключ действует в пространстве пользователя user_id;
+// повтор ключа с другим запросом отклоняется с 422. Ответы с ошибкой сервера не сохраняются,
+// чтобы запрос можно было повторить.
+func IdempotencyMiddleware(store domain.IdempotencyStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !validIdempotencyKey(key) {
+				problem.WriteError(w, r, domain.NewValidationError("ключ идемпотентности должен состоять из печатных ASCII-символов и быть не длиннее "+strconv.Itoa(MaxIdempotencyKeyLength)).WithCode(domain.CodeInvalidIdempotencyKey))
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, MaxIdempotentBodySize+1))
+			if err != nil {
+				problem.WriteError(w, r, domain.NewValidationError("ошибка чтения тела запроса"))
+				return
+			}
+			if len(body) > MaxIdempotentBodySize {
+				problem.WriteError(w, r, domain.NewAppError("тело запроса превышает допустимый размер", http.StatusRequestEntityTooLarge, nil).WithCode(domain.CodeRequestTooLarge))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			// Без пользователя ключ не к чему привязать; такой запрос отклонит обработчик
+			userID := requestUserID(r, body)
+			if userID <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			fingerprint := requestFingerprint(r, body)
+			record, reserved := store.Reserve(userID, key, fingerprint)
+			if !reserved {
+				switch {
+				case record.Fingerprint != fingerprint:
+					problem.WriteError(w, r, domain.NewAppError("ключ идемпотентности уже использован для другого запроса", domain.StatusUnprocessableEntity, nil).WithCode(domain.CodeIdempotencyKeyReused))
+				case record.Response == nil:
+					problem.WriteError(w, r, domain.NewAppError("запрос с этим ключом идемпотентности еще выполняется", domain.StatusConflict, nil).WithCode(domain.CodeIdempotencyInProgress))
+				default:
+					replayResponse(w, record.Response)
+				}
+				return
+			}
+
+			recorder := &recordingWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			defer func() {
+				if p := recover(); p != nil {
+					store.Release(userID, key)
+					panic(p)
+				}
+			}()
+
+			next.ServeHTTP(recorder, r)
+
+			if recorder.statusCode >= http.StatusInternalServerError {
+				store.Release(userID, key)
+				return
+			}
+			// ID запроса относится к конкретному запросу: повтор получает собственный ID
+			header := recorder.Header().Clone()
+			header.Del(RequestIDHeader)
+			store.Complete(userID, key, &domain.IdempotentResponse{
+				StatusCode: recorder.statusCode,
+				Header:     header,
+				Body:       recorder.body.Bytes(),
+			})
+		})
+	}
+}
+
+// validIdempotencyKey проверяет длину и символы ключа идемпотентности
+func validIdempotencyKey(key string) bool {
+	if len(key) > MaxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// requestFingerprint вычисляет отпечаток запроса по методу, пути, строке запроса и телу
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	for _, part := range []string{r.Method, r.URL.Path, r.URL.RawQuery, r.Header.Get("Content-Type")} {
+		io.WriteString(hash, part)
+		hash.Write([]byte{0})
+	}
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// replayResponse отдает сохраненный ответ
+func replayResponse(w http.ResponseWriter, response *domain.IdempotentResponse) {
+	for name, values := range response.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(response.StatusCode)
+	w.Write(response.Body)
+}
+
+// recordingWriter оборачивает http.ResponseWriter и запоминает статус-код и тело ответа
+type recordingWriter struct {
+	http.ResponseWriter
+	statusCode  int
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+// WriteHeader перехватывает статус-код
+func (rw *recordingWriter) WriteHeader(code int) {
+	if !rw.wroteHeader {
+		rw.statusCode = code
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+// Write перехватывает тело ответа
+func (rw *recordingWriter) Write(data []byte) (int, error) {
+	rw.wroteHeader = true
+	rw.body.Write(data)
+	return rw.ResponseWriter.Write(data)
+}
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"calendar/internal/domain"
+	"calendar/internal/infrastructure/repository"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// idempotentRequest создает запрос на создание события с ключом идемпотентности
+func idempotentRequest(key, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/create_event", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set(IdempotencyKeyHeader, key)
+	return r
+}
+
+// responseCode возвращает код ошибки из тела ответа
+func responseCode(t *testing.T, recorder *httptest.ResponseRecorder) domain.ErrorCode {
+	var response domain.Response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	return response.Code
+}
+
+func TestIdempotencyMiddleware_Replay(t *testing.T) {
+	calls := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"result":"` + string(body) + `"}`))
+	})
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	chain := LoggingMiddleware(logger)(IdempotencyMiddleware(repository.NewMemoryIdempotencyStore(time.Hour))(handler))
+
+	first := httptest.NewRecorder()
+	chain.ServeHTTP(first, idempotentRequest("key-1", "user_id=1&text=a"))
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+
+	// Повтор получает сохраненный ответ, обработчик не вызывается
+	second := httptest.NewRecorder()
+	chain.ServeHTTP(second, idempotentRequest("key-1", "user_id=1&text=a"))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "application/json", second.Header().Get("Content-Type"))
+	assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+
+	// У повтора собственный ID запроса
+	assert.NotEmpty(t, second.Header().Get(RequestIDHeader))
+	assert.NotEqual(t, first.Header().Get(RequestIDHeader), second.Header().Get(RequestIDHeader))
+
+	// Ключи разных пользователей независимы
+	other := httptest.NewRecorder()
+	chain.ServeHTTP(other, idempotentRequest("key-1", "user_id=2&text=a"))
+	assert.Equal(t, 2, calls)
+	assert.Empty(t, other.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestIdempotencyMiddleware_DifferentRequest(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	chain := IdempotencyMiddleware(repository.NewMemoryIdempotencyStore(time.Hour))(handler)
+
+	chain.ServeHTTP(httptest.NewRecorder(), idempotentRequest("key-1", "user_id=1&text=a"))
+
+	recorder := httptest.NewRecorder()
+	chain.ServeHTTP(recorder, idempotentRequest("key-1", "user_id=1&text=b"))
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Equal(t, domain.CodeIdempotencyKeyReused, responseCode(t, recorder))
+}
+
+func TestIdempotencyMiddleware_InProgress(t *testing.T) {
+	var chain http.Handler
+	inner := httptest.NewRecorder()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Повтор приходит, пока первый запрос еще выполняется
+		if inner.Body.Len() == 0 {
+			chain.ServeHTTP(inner, idempotentRequest("key-1", "user_id=1&text=a"))
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	chain = IdempotencyMiddleware(repository.NewMemoryIdempotencyStore(time.Hour))(handler)
+
+	recorder := httptest.NewRecorder()
+	chain.ServeHTTP(recorder, idempotentRequest("key-1", "user_id=1&text=a"))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	assert.Equal(t, http.StatusConflict, inner.Code)
+	assert.Equal(t, domain.CodeIdempotencyInProgress, responseCode(t, inner))
+}
+
+func TestIdempotencyMiddleware_ReleaseOnServerError(t *testing.T) {
+	calls := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	chain := IdempotencyMiddleware(repository.NewMemoryIdempotencyStore(time.Hour))(handler)
+
+	first := httptest.NewRecorder()
+	chain.ServeHTTP(first, idempotentRequest("key-1", "user_id=1&text=a"))
+	assert.Equal(t, http.StatusInternalServerError, first.Code)
+
+	// Ответ с ошибкой сервера не сохраняется, запрос можно повторить
+	second := httptest.NewRecorder()
+	chain.ServeHTTP(second, idempotentRequest("key-1", "user_id=1&text=a"))
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, 2, calls)
+	assert.Empty(t, second.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestIdempotencyMiddleware_ReleaseOnPanic(t *testing.T) {
+	calls := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			panic("сбой")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	chain := IdempotencyMiddleware(repository.NewMemoryIdempotencyStore(time.Hour))(handler)
+
+	// Паника передается дальше, в RecoveryMiddleware
+	assert.PanicsWithValue(t, "сбой", func() {
+		chain.ServeHTTP(httptest.NewRecorder(), idempotentRequest("key-1", "user_id=1&text=a"))
+	})
+
+	recorder := httptest.NewRecorder()
+	chain.ServeHTTP(recorder, idempotentRequest("key-1", "user_id=1&text=a"))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyMiddleware_InvalidKey(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	chain := IdempotencyMiddleware(repository.NewMemoryIdempotencyStore(time.Hour))(handler)
+
+	for _, key := range []string{"ключ", "key\n", strings.Repeat("k", MaxIdempotencyKeyLength+1)} {
+		recorder := httptest.NewRecorder()
+		chain.ServeHTTP(recorder, idempotentRequest(key, "user_id=1"))
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, domain.CodeInvalidIdempotencyKey, responseCode(t, recorder))
+	}
+}
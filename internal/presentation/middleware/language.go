@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"calendar/internal/domain"
+	"calendar/internal/infrastructure/i18n"
+	"calendar/internal/infrastructure/logging"
+)
+
+// LanguageMiddleware выбирает язык сообщений API и сохраняет его в контексте запроса.
+// Язык, выбранный пользователем в настройках, важнее заголовка Accept-Language;
+// если не подходит ни один из них, используется язык по умолчанию.
+// preference возвращает язык пользователя или пустую строку; ID пользователя берется
+// из контекста, поэтому middleware подключается после LoggingMiddleware.
+func LanguageMiddleware(preference func(ctx context.Context, userID int) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var lang string
+			if userID := logging.UserID(r.Context()); userID > 0 && preference != nil {
+				lang = preference(r.Context(), userID)
+			}
+			if !domain.IsSupportedLanguage(lang) {
+				lang = i18n.Negotiate(r.Header.Get("Accept-Language"))
+			}
+			if lang == "" {
+				lang = domain.DefaultLanguage
+			}
+
+			next.ServeHTTP(w, r.WithContext(i18n.WithLanguage(r.Context(), lang)))
+		})
+	}
+}
@@ -1,50 +1,123 @@
 package middleware
 
 import (
-	"fmt"
+	"calendar/internal/infrastructure/logging"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"mime"
 	"net/http"
 	"time"
 )
 
-// LoggingMiddleware логирует каждый HTTP-запрос
-func LoggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-
-		// Логируем начало запроса
-		fmt.Printf("[%s] %s %s - Начало запроса\n",
-			time.Now().Format("2006-01-02 15:04:05"),
-			r.Method,
-			r.URL.Path)
-
-		// Создаем wrapper для ResponseWriter для перехвата статус-кода
-		wrappedWriter := &responseWriter{
-			ResponseWriter: w,
-			statusCode:     http.StatusOK,
+const (
+	// RequestIDHeader - заголовок с ID запроса
+	RequestIDHeader = "X-Request-ID"
+	// MaxRequestIDLength - максимальная длина ID запроса, принимаемого от клиента
+	MaxRequestIDLength = 128
+	// maxLoggedBodySize - размер тела, в котором ищется user_id для журнала
+	maxLoggedBodySize = 1 << 20
+)
+
+// LoggingMiddleware записывает в журнал каждый HTTP-запрос. ID запроса берется из заголовка
+// X-Request-ID или генерируется, возвращается в том же заголовке ответа и вместе с user_id
+// запроса сохраняется в контексте, так что попадает во все записи журнала, сделанные при его обработке.
+func LoggingMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			requestID := r.Header.Get(RequestIDHeader)
+			if !validRequestID(requestID) {
+				requestID = newRequestID()
+			}
+			w.Header().Set(RequestIDHeader, requestID)
+
+			ctx := logging.WithRequestID(r.Context(), requestID)
+			if userID := loggedUserID(r); userID > 0 {
+				ctx = logging.WithUserID(ctx, userID)
+			}
+			r = r.WithContext(ctx)
+
+			// Создаем wrapper для ResponseWriter для перехвата статус-кода и размера ответа
+			wrappedWriter := &responseWriter{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+			}
+
+			// Выполняем следующий обработчик
+			next.ServeHTTP(wrappedWriter, r)
+
+			level := slog.LevelInfo
+			switch {
+			case wrappedWriter.statusCode >= http.StatusInternalServerError:
+				level = slog.LevelError
+			case wrappedWriter.statusCode >= http.StatusBadRequest:
+				level = slog.LevelWarn
+			}
+
+			logger.LogAttrs(ctx, level, "запрос обработан",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", wrappedWriter.statusCode),
+				slog.Int64("bytes", wrappedWriter.bytes),
+				slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+			)
+		})
+	}
+}
+
+// loggedUserID извлекает user_id запроса для журнала, не расходуя тело запроса
+func loggedUserID(r *http.Request) int {
+	var body []byte
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if r.Body != nil && (mediaType == "application/json" || mediaType == "application/x-www-form-urlencoded") {
+		body, _ = peekBody(r, maxLoggedBodySize)
+	}
+	return requestUserID(r, body)
+}
+
+// validRequestID проверяет ID запроса, полученный от клиента
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > MaxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] <= 0x20 || requestID[i] > 0x7e {
+			return false
 		}
+	}
+	return true
+}
 
-		// Выполняем следующий обработчик
-		next.ServeHTTP(wrappedWriter, r)
-
-		// Логируем завершение запроса
-		duration := time.Since(start)
-		fmt.Printf("[%s] %s %s - Завершение запроса (статус: %d, время: %v)\n",
-			time.Now().Format("2006-01-02 15:04:05"),
-			r.Method,
-			r.URL.Path,
-			wrappedWriter.statusCode,
-			duration)
-	})
+// newRequestID генерирует случайный ID запроса
+func newRequestID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
 }
 
-// responseWriter оборачивает http.ResponseWriter для перехвата статус-кода
+// responseWriter оборачивает http.ResponseWriter для перехвата статус-кода и размера ответа
 type responseWriter struct {
 	http.ResponseWriter
-	statusCode int
+	statusCode  int
+	bytes       int64
+	wroteHeader bool
 }
 
 // WriteHeader перехватывает статус-код
 func (rw *responseWriter) WriteHeader(code int) {
-	rw.statusCode = code
+	if !rw.wroteHeader {
+		rw.statusCode = code
+		rw.wroteHeader = true
+	}
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Write подсчитывает размер ответа
+func (rw *responseWriter) Write(data []byte) (int, error) {
+	rw.wroteHeader = true
+	n, err := rw.ResponseWriter.Write(data)
+	rw.bytes += int64(n)
+	return n, err
+}
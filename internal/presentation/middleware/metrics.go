@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"calendar/internal/infrastructure/metrics"
+	"net/http"
+	"time"
+)
+
+// unmatchedRoute - метка маршрута для запросов, которым роутер не нашел маршрут
+const unmatchedRoute = "unknown"
+
+// MetricsMiddleware учитывает каждый HTTP-запрос в метриках по шаблону маршрута mux,
+// чтобы число временных рядов не зависело от параметров в пути. Оборачивается вокруг роутера
+// вместе с RouteMiddleware внутри него, чтобы учитывались и ответы 404 и 405.
+func MetricsMiddleware(m *metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			r, route := withMatchedRoute(r)
+
+			wrappedWriter := &responseWriter{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+			}
+
+			next.ServeHTTP(wrappedWriter, r)
+
+			label := routeTemplate(r, route)
+			if label == "" {
+				label = unmatchedRoute
+			}
+			m.ObserveRequest(label, r.Method, wrappedWriter.statusCode, time.Since(start))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"calendar/internal/domain"
+	"calendar/internal/presentation/problem"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecoveryMiddleware перехватывает панику в обработчике, записывает ее в журнал вместе со стеком
+// и отвечает стандартной ошибкой 500. Если ответ уже начал отправляться, изменить его нельзя,
+// и паника только записывается в журнал. http.ErrAbortHandler пробрасывается дальше:
+// им обработчик намеренно прерывает ответ.
+func RecoveryMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wrappedWriter := &responseWriter{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+			}
+
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+
+				span := trace.SpanFromContext(r.Context())
+				span.RecordError(fmt.Errorf("паника: %v", p), trace.WithStackTrace(true))
+				span.SetStatus(codes.Error, "паника в обработчике")
+
+				logger.ErrorContext(r.Context(), "паника при обработке запроса",
+					"panic", fmt.Sprint(p),
+					"stack", string(debug.Stack()),
+				)
+
+				if !wrappedWriter.wroteHeader {
+					problem.WriteError(wrappedWriter, r, domain.NewInternalError("Внутренняя ошибка сервера", nil))
+				}
+			}()
+
+			next.ServeHTTP(wrappedWriter, r)
+		})
+	}
+}
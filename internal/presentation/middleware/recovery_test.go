@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"bytes"
+	"calendar/internal/domain"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoveryMiddleware_Panic(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	handler := RecoveryMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("сбой")
+	}))
+
+	recorder := httptest.NewRecorder()
+	require.NotPanics(t, func() {
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/create_event", nil))
+	})
+
+	// Клиент получает стандартную ошибку 500 без подробностей паники
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	var response domain.Response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, domain.CodeInternal, response.Code)
+	assert.NotContains(t, recorder.Body.String(), "сбой")
+
+	// Паника и стек записываются в журнал
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "ERROR", entry["level"])
+	assert.Equal(t, "сбой", entry["panic"])
+	assert.Contains(t, entry["stack"], "TestRecoveryMiddleware_Panic")
+}
+
+func TestRecoveryMiddleware_HeaderAlreadyWritten(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	handler := RecoveryMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("частичный ответ"))
+		panic("сбой после начала ответа")
+	}))
+
+	recorder := httptest.NewRecorder()
+	require.NotPanics(t, func() {
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/events_for_day", nil))
+	})
+
+	// Отправленный ответ не меняется, паника только записывается в журнал
+	assert.Equal(t, http.StatusAccepted, recorder.Code)
+	assert.Equal(t, "text/plain", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "частичный ответ", recorder.Body.String())
+	assert.Contains(t, logs.String(), "сбой после начала ответа")
+}
+
+func TestRecoveryMiddleware_ErrAbortHandler(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	handler := RecoveryMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	// Намеренное прерывание ответа передается серверу, который закрывает соединение
+	recorder := httptest.NewRecorder()
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/events_for_day", nil))
+	})
+	assert.Empty(t, recorder.Body.String())
+	assert.Empty(t, logs.String())
+}
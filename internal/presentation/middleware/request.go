@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// requestUserID извлекает user_id из JSON-тела, формы или строки запроса; 0, если его нет
+func requestUserID(r *http.Request, body []byte) int {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	var value string
+	switch mediaType {
+	case "application/json":
+		var payload struct {
+			UserID json.Number `json:"user_id"`
+		}
+		if json.Unmarshal(body, &payload) == nil {
+			value = payload.UserID.String()
+		}
+	case "application/x-www-form-urlencoded":
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			value = form.Get("user_id")
+		}
+	}
+	if value == "" {
+		value = r.URL.Query().Get("user_id")
+	}
+
+	userID, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	return userID
+}
+
+// peekBody читает тело запроса размером до limit байт и возвращает его на место.
+// Если тело длиннее, прочитанная часть не возвращается, а r.Body по-прежнему содержит все тело.
+func peekBody(r *http.Request, limit int64) ([]byte, bool) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+	if err != nil || int64(len(body)) > limit {
+		return nil, false
+	}
+	return body, true
+}
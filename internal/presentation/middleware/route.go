@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// routeKey - ключ контекста с маршрутом, найденным роутером
+type routeKey struct{}
+
+// matchedRoute хранит шаблон маршрута mux; пустой, если роутер не нашел маршрут
+type matchedRoute struct {
+	template string
+}
+
+// withMatchedRoute добавляет в контекст запроса место для шаблона маршрута, если его там еще нет.
+// Так middleware, обернутые вокруг роутера, узнают маршрут после обработки запроса.
+func withMatchedRoute(r *http.Request) (*http.Request, *matchedRoute) {
+	if route, ok := r.Context().Value(routeKey{}).(*matchedRoute); ok {
+		return r, route
+	}
+
+	route := &matchedRoute{}
+	return r.WithContext(context.WithValue(r.Context(), routeKey{}, route)), route
+}
+
+// RouteMiddleware сохраняет шаблон маршрута mux для middleware, обернутых вокруг роутера.
+// Регистрируется через router.Use, поэтому выполняется только для найденных маршрутов.
+func RouteMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route, ok := r.Context().Value(routeKey{}).(*matchedRoute); ok {
+			route.template = currentRouteTemplate(r)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// routeTemplate возвращает шаблон маршрута запроса после его обработки; пустую строку,
+// если маршрут не найден (404, 405)
+func routeTemplate(r *http.Request, route *matchedRoute) string {
+	if route.template != "" {
+		return route.template
+	}
+	// Middleware, зарегистрированный внутри роутера, видит маршрут напрямую
+	return currentRouteTemplate(r)
+}
+
+// currentRouteTemplate возвращает шаблон маршрута mux, с которым сопоставлен запрос
+func currentRouteTemplate(r *http.Request) string {
+	if current := mux.CurrentRoute(r); current != nil {
+		if template, err := current.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName - имя трассировщика HTTP-слоя
+const tracerName = "calendar/internal/presentation"
+
+// TracingMiddleware открывает серверный спан на каждый HTTP-запрос. Родительский контекст трассы
+// берется из заголовков W3C traceparent и baggage. Оборачивается вокруг роутера вместе с RouteMiddleware
+// внутри него: спан открывается до поиска маршрута, поэтому называется по методу и после обработки
+// переименовывается по шаблону маршрута mux, если маршрут найден.
+func TracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		ctx, span := otel.Tracer(tracerName).Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+			),
+		)
+		defer span.End()
+
+		r, route := withMatchedRoute(r.WithContext(ctx))
+
+		wrappedWriter := &responseWriter{
+			ResponseWriter: w,
+			statusCode:     http.StatusOK,
+		}
+
+		next.ServeHTTP(wrappedWriter, r)
+
+		if template := routeTemplate(r, route); template != "" {
+			span.SetName(r.Method + " " + template)
+			span.SetAttributes(attribute.String("http.route", template))
+		}
+		span.SetAttributes(
+			attribute.Int("http.response.status_code", wrappedWriter.statusCode),
+			attribute.Int64("http.response.body.size", wrappedWriter.bytes),
+		)
+		if wrappedWriter.statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(wrappedWriter.statusCode))
+		}
+	})
+}
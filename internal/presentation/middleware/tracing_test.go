@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingMiddleware_AroundRouter(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	router := mux.NewRouter()
+	router.Use(RouteMiddleware)
+	router.HandleFunc("/events/{id}", func(w http.ResponseWriter, r *http.Request) {
+		// Обработчик работает в контексте спана запроса
+		assert.True(t, trace.SpanFromContext(r.Context()).SpanContext().IsValid())
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods(http.MethodGet)
+	handler := TracingMiddleware(router)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedName   string
+		expectedRoute  string
+	}{
+		{name: "Найденный маршрут", method: http.MethodGet, path: "/events/42", expectedStatus: http.StatusNoContent, expectedName: "GET /events/{id}", expectedRoute: "/events/{id}"},
+		{name: "Маршрут не найден", method: http.MethodGet, path: "/unknown", expectedStatus: http.StatusNotFound, expectedName: "GET"},
+		{name: "Метод не поддерживается", method: http.MethodDelete, path: "/events/42", expectedStatus: http.StatusMethodNotAllowed, expectedName: "DELETE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder.Reset()
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, httptest.NewRequest(tt.method, tt.path, nil))
+			require.Equal(t, tt.expectedStatus, response.Code)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, tt.expectedName, span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+
+			attributes := make(map[attribute.Key]attribute.Value)
+			for _, kv := range span.Attributes() {
+				attributes[kv.Key] = kv.Value
+			}
+			assert.Equal(t, int64(tt.expectedStatus), attributes["http.response.status_code"].AsInt64())
+			assert.Equal(t, tt.path, attributes["url.path"].AsString())
+			route, ok := attributes["http.route"]
+			assert.Equal(t, tt.expectedRoute != "", ok)
+			assert.Equal(t, tt.expectedRoute, route.AsString())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package problem
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+
+	"calendar/internal/domain"
+	"calendar/internal/infrastructure/i18n"
+	"calendar/internal/infrastructure/logging"
+)
+
+const (
+	// ContentType - MIME-тип ответа с ошибкой по RFC 7807
+	ContentType = "application/problem+json"
+
+	// typePrefix - префикс URI типа ошибки; тип однозначно соответствует коду ошибки
+	typePrefix = "urn:calendar:error:"
+)
+
+// Accepts сообщает, запросил ли клиент ошибки в формате application/problem+json через заголовок Accept
+func Accepts(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != ContentType {
+			continue
+		}
+		if q := params["q"]; q != "" && strings.Trim(q, "0.") == "" {
+			// q=0 означает, что формат неприемлем
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// New формирует описание ошибки appErr в формате RFC 7807 на языке запроса.
+// События conflicts, с которыми пересекается событие из запроса, добавляются в описание.
+func New(r *http.Request, appErr *domain.AppError, conflicts ...*domain.Event) *domain.ProblemDetails {
+	lang := i18n.Language(r.Context())
+	code := appErr.ErrorCode()
+	detail, fields := i18n.Localize(lang, appErr)
+	return &domain.ProblemDetails{
+		Type:      typePrefix + string(code),
+		Title:     i18n.Title(lang, code),
+		Status:    appErr.GetStatusCode(),
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		Code:      code,
+		RequestID: logging.RequestID(r.Context()),
+		Errors:    fields,
+		Conflicts: conflicts,
+	}
+}
+
+// WriteError записывает ошибку appErr в формате, запрошенном клиентом: application/problem+json,
+// если он указан в заголовке Accept, иначе стандартный ответ API {"error": ..., "code": ...}.
+// Сообщения переводятся на язык из контекста запроса, см. i18n.Language.
+// События conflicts, с которыми пересекается событие из запроса, добавляются в ответ.
+func WriteError(w http.ResponseWriter, r *http.Request, appErr *domain.AppError, conflicts ...*domain.Event) {
+	lang := i18n.Language(r.Context())
+	w.Header().Add("Vary", "Accept")
+	w.Header().Add("Vary", "Accept-Language")
+	w.Header().Set("Content-Language", lang)
+	if Accepts(r) {
+		w.Header().Set("Content-Type", ContentType)
+		w.WriteHeader(appErr.GetStatusCode())
+		json.NewEncoder(w).Encode(New(r, appErr, conflicts...))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.GetStatusCode())
+	message, fields := i18n.Localize(lang, appErr)
+	json.NewEncoder(w).Encode(domain.Response{
+		Error:     message,
+		Code:      appErr.ErrorCode(),
+		Details:   fields,
+		Conflicts: conflicts,
+	})
+}
@@ -0,0 +1,105 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	"calendar/internal/infrastructure/i18n"
+	"calendar/internal/infrastructure/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccepts(t *testing.T) {
+	tests := []struct {
+		name     string
+		accept   string
+		expected bool
+	}{
+		{name: "Без заголовка", accept: "", expected: false},
+		{name: "Только JSON", accept: "application/json", expected: false},
+		{name: "Любой тип", accept: "*/*", expected: false},
+		{name: "Problem JSON", accept: "application/problem+json", expected: true},
+		{name: "Регистр не важен", accept: "Application/Problem+JSON", expected: true},
+		{name: "С параметрами", accept: "application/problem+json; charset=utf-8", expected: true},
+		{name: "Среди нескольких типов", accept: "text/html, application/json;q=0.9, application/problem+json;q=0.8", expected: true},
+		{name: "Ненулевой вес", accept: "application/problem+json;q=0.5", expected: true},
+		{name: "Нулевой вес", accept: "application/problem+json;q=0", expected: false},
+		{name: "Нулевой вес с дробной частью", accept: "application/json, application/problem+json; q=0.000", expected: false},
+		{name: "Некорректный тип пропускается", accept: "application/;;, application/problem+json", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/events_for_day", nil)
+			r.Header.Set("Accept", tt.accept)
+			assert.Equal(t, tt.expected, Accepts(r))
+		})
+	}
+}
+
+func TestWriteError_Standard(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/create_event", nil)
+	r.Header.Set("Accept", "application/json")
+	recorder := httptest.NewRecorder()
+
+	WriteError(recorder, r, domain.NewFieldError("date", domain.CodeInvalidDate, "некорректный формат даты"))
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, domain.DefaultLanguage, recorder.Header().Get("Content-Language"))
+	assert.ElementsMatch(t, []string{"Accept", "Accept-Language"}, recorder.Header().Values("Vary"))
+
+	var response domain.Response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "некорректный формат даты", response.Error)
+	assert.Equal(t, domain.CodeInvalidDate, response.Code)
+	assert.Equal(t, []domain.FieldError{{Field: "date", Code: domain.CodeInvalidDate, Message: "некорректный формат даты"}}, response.Details)
+	assert.Empty(t, response.Conflicts)
+}
+
+func TestWriteError_Problem(t *testing.T) {
+	ctx := logging.WithRequestID(i18n.WithLanguage(httptest.NewRequest(http.MethodGet, "/", nil).Context(), domain.LanguageEnglish), "req-1")
+	r := httptest.NewRequest(http.MethodPost, "/create_event", nil).WithContext(ctx)
+	r.Header.Set("Accept", "application/problem+json")
+	recorder := httptest.NewRecorder()
+
+	conflicts := []*domain.Event{{ID: 7, UserID: 1, Date: time.Date(2025, 12, 18, 10, 0, 0, 0, time.UTC), Text: "Встреча"}}
+	WriteError(recorder, r, domain.NewConflictError("событие пересекается с существующими событиями", conflicts).AppError, conflicts...)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+	assert.Equal(t, ContentType, recorder.Header().Get("Content-Type"))
+	assert.Equal(t, domain.LanguageEnglish, recorder.Header().Get("Content-Language"))
+
+	var details domain.ProblemDetails
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &details))
+	assert.Equal(t, "urn:calendar:error:EVENT_CONFLICT", details.Type)
+	assert.Equal(t, "Event overlaps existing events", details.Title)
+	assert.Equal(t, http.StatusConflict, details.Status)
+	assert.Equal(t, "Event overlaps existing events", details.Detail)
+	assert.Equal(t, "/create_event", details.Instance)
+	assert.Equal(t, domain.CodeEventConflict, details.Code)
+	assert.Equal(t, "req-1", details.RequestID)
+	assert.Empty(t, details.Errors)
+	require.Len(t, details.Conflicts, 1)
+	assert.Equal(t, 7, details.Conflicts[0].ID)
+}
+
+func TestNew_InternalError(t *testing.T) {
+	// Причина внутренней ошибки в описание не попадает ни на каком языке
+	for _, lang := range []string{domain.LanguageRussian, domain.LanguageEnglish} {
+		r := httptest.NewRequest(http.MethodPost, "/delete_event", nil)
+		r = r.WithContext(i18n.WithLanguage(r.Context(), lang))
+
+		details := New(r, domain.NewInternalError("ошибка при удалении события", assert.AnError))
+		assert.Equal(t, http.StatusInternalServerError, details.Status, lang)
+		assert.Equal(t, domain.CodeInternal, details.Code, lang)
+		assert.NotEmpty(t, details.Detail, lang)
+		assert.NotContains(t, details.Detail, assert.AnError.Error(), lang)
+	}
+}
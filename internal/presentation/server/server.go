@@ -1,10 +1,18 @@
 package server
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"sync/atomic"
+	"time"
 
 	"calendar/internal/application"
+	"calendar/internal/infrastructure/health"
+	"calendar/internal/infrastructure/metrics"
 	"calendar/internal/infrastructure/repository"
 	"calendar/internal/presentation/handler"
 	"calendar/internal/presentation/middleware"
@@ -12,37 +20,173 @@ import (
 	"github.com/gorilla/mux"
 )
 
+// idempotencyCleanupInterval - период очистки истекших ключей идемпотентности
+const idempotencyCleanupInterval = time.Minute
+
+// Значения параметров сервера по умолчанию
+const (
+	DefaultIdempotencyTTL    = 24 * time.Hour
+	DefaultReadHeaderTimeout = 5 * time.Second
+	DefaultReadTimeout       = 15 * time.Second
+	DefaultWriteTimeout      = 30 * time.Second
+	DefaultIdleTimeout       = 60 * time.Second
+	DefaultShutdownTimeout   = 15 * time.Second
+	DefaultReadinessTimeout  = 2 * time.Second
+)
+
+// Config содержит параметры HTTP-сервера. Незаданные (нулевые) длительности заменяются значениями по умолчанию.
+type Config struct {
+	Port              string
+	IdempotencyTTL    time.Duration        // срок хранения ответов на запросы с ключом идемпотентности
+	ReadHeaderTimeout time.Duration        // время на чтение заголовков запроса
+	ReadTimeout       time.Duration        // время на чтение всего запроса
+	WriteTimeout      time.Duration        // время от окончания чтения заголовков до записи ответа
+	IdleTimeout       time.Duration        // время ожидания следующего запроса по keep-alive соединению
+	ShutdownTimeout   time.Duration        // время на завершение текущих запросов при остановке
+	ShutdownDelay     time.Duration        // пауза между снятием готовности и остановкой приема запросов; 0 - без паузы
+	ReadinessTimeout  time.Duration        // время на одну проверку готовности в /readyz
+	StatusPolicy      handler.StatusPolicy // статус-коды ошибок бизнес-логики; по умолчанию strict
+	Logger            *slog.Logger         // журнал запросов; по умолчанию slog.Default()
+}
+
+// withDefaults возвращает конфигурацию, в которой незаданные длительности заменены значениями по умолчанию
+func (c Config) withDefaults() Config {
+	for _, d := range []struct {
+		value *time.Duration
+		def   time.Duration
+	}{
+		{&c.IdempotencyTTL, DefaultIdempotencyTTL},
+		{&c.ReadHeaderTimeout, DefaultReadHeaderTimeout},
+		{&c.ReadTimeout, DefaultReadTimeout},
+		{&c.WriteTimeout, DefaultWriteTimeout},
+		{&c.IdleTimeout, DefaultIdleTimeout},
+		{&c.ShutdownTimeout, DefaultShutdownTimeout},
+		{&c.ReadinessTimeout, DefaultReadinessTimeout},
+	} {
+		if *d.value <= 0 {
+			*d.value = d.def
+		}
+	}
+	if c.ShutdownDelay < 0 {
+		c.ShutdownDelay = 0
+	}
+	if c.Logger == nil {
+		c.Logger = slog.Default()
+	}
+	if c.StatusPolicy == "" {
+		c.StatusPolicy = handler.StatusPolicyStrict
+	}
+	return c
+}
+
 // Server представляет HTTP-сервер
 type Server struct {
-	router       *mux.Router
-	port         string
-	eventHandler *handler.EventHandler
+	router          *mux.Router
+	httpServer      *http.Server
+	port            string
+	shutdownTimeout time.Duration
+	shutdownDelay   time.Duration
+	logger          *slog.Logger
+	idempotency     *repository.MemoryIdempotencyStore
+	metrics         *metrics.Metrics
+	readiness       *health.Registry
+	draining        atomic.Bool // сервер останавливается и не должен получать новые запросы
+	eventHandler    *handler.EventHandler
+	settingsHandler *handler.SettingsHandler
+	tagHandler      *handler.TagHandler
 }
 
 // NewServer создает новый экземпляр HTTP-сервера
-func NewServer(port string) *Server {
-	// Создаем репозиторий
+func NewServer(cfg Config) *Server {
+	cfg = cfg.withDefaults()
+
+	// Создаем репозитории
 	eventRepo := repository.NewMemoryEventRepository()
+	settingsRepo := repository.NewMemoryUserSettingsRepository()
+	tagRepo := repository.NewMemoryTagRepository()
+	idempotencyStore := repository.NewMemoryIdempotencyStore(cfg.IdempotencyTTL)
+
+	// Создаем сервисы приложения; вызовы репозитория событий трассируются
+	tracedEventRepo := repository.NewTracedEventRepository(eventRepo, "memory")
+	eventService := application.NewEventService(tracedEventRepo, tagRepo)
+	settingsService := application.NewUserSettingsService(settingsRepo)
+	tagService := application.NewTagService(tagRepo, tracedEventRepo)
 
-	// Создаем сервис приложения
-	eventService := application.NewEventService(eventRepo)
+	// Создаем обработчики
+	eventHandler := handler.NewEventHandler(eventService, settingsService)
+	settingsHandler := handler.NewSettingsHandler(settingsService)
+	tagHandler := handler.NewTagHandler(tagService)
 
-	// Создаем обработчик
-	eventHandler := handler.NewEventHandler(eventService)
+	// Метрики
+	serverMetrics := metrics.New()
+	serverMetrics.RegisterEventRepository("memory", eventRepo)
+	eventHandler.SetStatusPolicy(cfg.StatusPolicy)
+	settingsHandler.SetStatusPolicy(cfg.StatusPolicy)
+	tagHandler.SetStatusPolicy(cfg.StatusPolicy)
+	eventHandler.ObserveErrors(serverMetrics.ObserveAppError)
+	settingsHandler.ObserveErrors(serverMetrics.ObserveAppError)
+	tagHandler.ObserveErrors(serverMetrics.ObserveAppError)
+
+	// Проверки готовности
+	readiness := health.NewRegistry(cfg.ReadinessTimeout)
+	readiness.Register("event_repository", 0, eventRepo.Ping)
+	readiness.Register("idempotency_cleanup", 0, func(ctx context.Context) error {
+		if !idempotencyStore.CleanupRunning() {
+			return errors.New("очистка ключей идемпотентности не запущена")
+		}
+		return nil
+	})
 
 	// Создаем роутер
 	router := mux.NewRouter()
 
-	// middleware
-	router.Use(middleware.LoggingMiddleware)
+	// middleware маршрутов; mux выполняет их только для найденных маршрутов
+	router.Use(middleware.RouteMiddleware)
+	router.Use(middleware.LanguageMiddleware(func(ctx context.Context, userID int) string {
+		// Ошибка чтения настроек не мешает обработке запроса: язык выберется по Accept-Language
+		lang, _ := settingsService.Language(userID)
+		return lang
+	}))
+	router.Use(middleware.RecoveryMiddleware(cfg.Logger))
+	router.Use(middleware.IdempotencyMiddleware(idempotencyStore))
+
+	// Трассировка, журнал и метрики оборачивают роутер, чтобы учитывать и ответы 404 и 405
+	var root http.Handler = router
+	root = middleware.MetricsMiddleware(serverMetrics)(root)
+	root = middleware.LoggingMiddleware(cfg.Logger)(root)
+	root = middleware.TracingMiddleware(root)
 
 	// Создаем сервер
 	server := &Server{
-		router:       router,
-		port:         port,
-		eventHandler: eventHandler,
+		router: router,
+		httpServer: &http.Server{
+			Addr:              ":" + cfg.Port,
+			Handler:           root,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			ReadTimeout:       cfg.ReadTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+		},
+		port:            cfg.Port,
+		shutdownTimeout: cfg.ShutdownTimeout,
+		shutdownDelay:   cfg.ShutdownDelay,
+		logger:          cfg.Logger,
+		idempotency:     idempotencyStore,
+		metrics:         serverMetrics,
+		readiness:       readiness,
+		eventHandler:    eventHandler,
+		settingsHandler: settingsHandler,
+		tagHandler:      tagHandler,
 	}
 
+	// Остановка сервера делает его неготовым, чтобы балансировщик перестал направлять запросы
+	readiness.Register("shutdown", 0, func(ctx context.Context) error {
+		if server.draining.Load() {
+			return errors.New("сервер останавливается")
+		}
+		return nil
+	})
+
 	// Настраиваем маршруты
 	server.setupRoutes()
 
@@ -53,21 +197,89 @@ func NewServer(port string) *Server {
 func (s *Server) setupRoutes() {
 	// Регистрируем маршруты для событий
 	s.eventHandler.RegisterRoutes(s.router)
+	s.settingsHandler.RegisterRoutes(s.router)
+	s.tagHandler.RegisterRoutes(s.router)
+
+	// Пробы живости и готовности; /health оставлен для совместимости и равен /livez
+	s.router.HandleFunc("/livez", s.liveness).Methods("GET")
+	s.router.HandleFunc("/health", s.liveness).Methods("GET")
+	s.router.HandleFunc("/readyz", s.readinessCheck).Methods("GET")
 
-	// Добавляем health check endpoint
-	s.router.HandleFunc("/health", s.healthCheck).Methods("GET")
+	// Метрики в формате Prometheus
+	s.router.Handle("/metrics", s.metrics.Handler()).Methods("GET")
 }
 
-// healthCheck обрабатывает запрос на проверку состояния сервера
-func (s *Server) healthCheck(w http.ResponseWriter, r *http.Request) {
+// liveness отвечает, что процесс работает и обрабатывает запросы; зависимости не проверяются
+func (s *Server) liveness(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	fmt.Fprintf(w, `{"status": "ok", "service": "calendar"}`)
 }
 
-// Start запускает HTTP-сервер
-func (s *Server) Start() error {
-	addr := ":" + s.port
-	fmt.Printf("Сервер запущен на порту %s\n", s.port)
-	return http.ListenAndServe(addr, s.router)
+// readinessCheck выполняет проверки готовности и возвращает их результаты;
+// если хотя бы одна проверка не прошла, отвечает 503
+func (s *Server) readinessCheck(w http.ResponseWriter, r *http.Request) {
+	report := s.readiness.Run(r.Context())
+
+	status := http.StatusOK
+	if report.Status != health.StatusOK {
+		status = http.StatusServiceUnavailable
+		var failed []string
+		for _, check := range report.Checks {
+			if check.Status != health.StatusOK {
+				failed = append(failed, check.Name+": "+check.Error)
+			}
+		}
+		s.logger.WarnContext(r.Context(), "сервер не готов", "failed_checks", failed)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
+
+// Run запускает HTTP-сервер и фоновые задачи и работает до отмены ctx.
+// После отмены /readyz начинает отвечать 503, но запросы еще ShutdownDelay принимаются, пока
+// балансировщик не заметит неготовность. Затем сервер перестает принимать соединения и ждет
+// завершения текущих запросов не дольше ShutdownTimeout, после чего останавливает фоновые задачи.
+func (s *Server) Run(ctx context.Context) error {
+	stopCleanup := s.idempotency.StartCleanup(idempotencyCleanupInterval)
+	defer stopCleanup()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		s.logger.Info("сервер запущен", "port", s.port)
+		serveErr <- s.httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		// Сервер не смог запуститься, например порт занят
+		return err
+	case <-ctx.Done():
+	}
+
+	s.draining.Store(true)
+	if s.shutdownDelay > 0 {
+		s.logger.Info("сервер снят с готовности, ожидание перед остановкой", "delay", s.shutdownDelay.String())
+		time.Sleep(s.shutdownDelay)
+	}
+
+	s.logger.Info("остановка сервера", "timeout", s.shutdownTimeout.String())
+	return s.shutdown()
+}
+
+// shutdown останавливает HTTP-сервер, дожидаясь завершения текущих запросов не дольше shutdownTimeout
+func (s *Server) shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		// Закрытие соединений отменяет контексты незавершенных запросов
+		s.httpServer.Close()
+		return fmt.Errorf("текущие запросы не завершились за %v: %w", s.shutdownTimeout, err)
+	}
+
+	return nil
 }
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// freePort возвращает свободный TCP-порт на localhost
+func freePort(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func TestConfig_WithDefaults(t *testing.T) {
+	cfg := Config{ShutdownTimeout: time.Second, ShutdownDelay: -time.Second}.withDefaults()
+
+	assert.Equal(t, time.Second, cfg.ShutdownTimeout)
+	assert.Equal(t, DefaultReadinessTimeout, cfg.ReadinessTimeout)
+	// Пауза перед остановкой по умолчанию не нужна, отрицательная равна нулю
+	assert.Zero(t, cfg.ShutdownDelay)
+	assert.NotNil(t, cfg.Logger)
+}
+
+func TestServer_RunShutdownSequence(t *testing.T) {
+	const delay = 300 * time.Millisecond
+
+	port := freePort(t)
+	srv := NewServer(Config{
+		Port:          port,
+		ShutdownDelay: delay,
+		Logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runErr := make(chan error, 1)
+	go func() { runErr <- srv.Run(ctx) }()
+
+	client := &http.Client{Timeout: time.Second}
+	readyz := func() int {
+		resp, err := client.Get("http://127.0.0.1:" + port + "/readyz")
+		if err != nil {
+			return 0
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// Сервер запущен и готов
+	require.Eventually(t, func() bool { return readyz() == http.StatusOK }, 2*time.Second, 10*time.Millisecond)
+
+	// После отмены сервер снят с готовности, но еще принимает запросы
+	stopped := time.Now()
+	cancel()
+	require.Eventually(t, func() bool { return readyz() == http.StatusServiceUnavailable }, delay, 5*time.Millisecond)
+
+	resp, err := client.Get("http://127.0.0.1:" + port + "/livez")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Остановка приема запросов начинается не раньше окончания паузы
+	select {
+	case err := <-runErr:
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(stopped), delay)
+	case <-time.After(5 * time.Second):
+		t.Fatal("сервер не остановился")
+	}
+
+	_, err = client.Get("http://127.0.0.1:" + port + "/livez")
+	assert.Error(t, err)
+}
+
+func TestServer_UnmatchedRequestsLoggedAndCounted(t *testing.T) {
+	var logs bytes.Buffer
+	srv := NewServer(Config{Logger: slog.New(slog.NewJSONHandler(&logs, nil))})
+	handler := srv.httpServer.Handler
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+		return recorder
+	}
+
+	require.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/no-such-route").Code)
+	require.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodDelete, "/livez").Code)
+	require.Equal(t, http.StatusOK, serve(http.MethodGet, "/livez").Code)
+
+	// Ответы без маршрута попадают в журнал
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if entry["msg"] == "запрос обработан" {
+			entries = append(entries, entry)
+		}
+	}
+	require.Len(t, entries, 3)
+	assert.Equal(t, "/no-such-route", entries[0]["path"])
+	assert.Equal(t, float64(http.StatusNotFound), entries[0]["status"])
+	assert.Equal(t, "WARN", entries[0]["level"])
+	assert.Equal(t, float64(http.StatusMethodNotAllowed), entries[1]["status"])
+	assert.NotEmpty(t, serve(http.MethodGet, "/no-such-route").Header().Get("X-Request-ID"))
+
+	// и в метрики: без маршрута с меткой unknown, найденные - по шаблону маршрута
+	metrics := serve(http.MethodGet, "/metrics").Body.String()
+	assert.Contains(t, metrics, `calendar_http_requests_total{method="GET",route="unknown",status="404"} 2`)
+	assert.Contains(t, metrics, `calendar_http_requests_total{method="DELETE",route="unknown",status="405"} 1`)
+	assert.Contains(t, metrics, `calendar_http_requests_total{method="GET",route="/livez",status="200"} 1`)
+}
@@ -1,26 +1,137 @@
 package main
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata" // встроенная база часовых поясов для окружений без zoneinfo
 
+	"calendar/internal/infrastructure/logging"
+	"calendar/internal/infrastructure/tracing"
+	"calendar/internal/presentation/handler"
 	"calendar/internal/presentation/server"
 )
 
 func main() {
+	// Журнал: формат LOG_FORMAT (json или text) и уровень LOG_LEVEL (debug, info, warn, error)
+	format := os.Getenv("LOG_FORMAT")
+	if format == "" {
+		format = logging.FormatJSON
+	}
+	var level slog.Level
+	if value := os.Getenv("LOG_LEVEL"); value != "" {
+		if err := level.UnmarshalText([]byte(value)); err != nil {
+			fatal("Некорректное значение LOG_LEVEL", "value", value)
+		}
+	}
+	logger, err := logging.New(os.Stdout, format, level)
+	if err != nil {
+		fatal("Некорректное значение LOG_FORMAT", "error", err)
+	}
+	slog.SetDefault(logger)
+
+	// Трассировка: экспортер OTEL_TRACES_EXPORTER (none, console или otlp), по умолчанию выключена
+	shutdownTracing, err := tracing.Setup(context.Background(), os.Getenv("OTEL_TRACES_EXPORTER"))
+	if err != nil {
+		fatal("Ошибка настройки трассировки", "error", err)
+	}
+
 	// Получаем порт из переменной окружения или используем значение по умолчанию
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8081"
 	}
 
+	statusPolicy, err := handler.ParseStatusPolicy(os.Getenv("ERROR_STATUS_POLICY"))
+	if err != nil {
+		fatal("Некорректное значение ERROR_STATUS_POLICY", "error", err)
+	}
+
+	cfg := server.Config{
+		Port:              port,
+		IdempotencyTTL:    durationFromEnv("IDEMPOTENCY_TTL"),
+		ReadHeaderTimeout: durationFromEnv("HTTP_READ_HEADER_TIMEOUT"),
+		ReadTimeout:       durationFromEnv("HTTP_READ_TIMEOUT"),
+		WriteTimeout:      durationFromEnv("HTTP_WRITE_TIMEOUT"),
+		IdleTimeout:       durationFromEnv("HTTP_IDLE_TIMEOUT"),
+		ShutdownTimeout:   durationFromEnv("SHUTDOWN_TIMEOUT"),
+		ShutdownDelay:     delayFromEnv("SHUTDOWN_DELAY"),
+		ReadinessTimeout:  durationFromEnv("READINESS_CHECK_TIMEOUT"),
+		StatusPolicy:      statusPolicy,
+		Logger:            logger,
+	}
+
 	// Создаем и запускаем сервер
-	srv := server.NewServer(port)
+	srv := server.NewServer(cfg)
+
+	slog.Info("сервер календаря запускается", "port", port, "health_check", "http://localhost:"+port+"/health")
+
+	// SIGINT и SIGTERM запускают плавную остановку сервера
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	log.Printf("Сервер календаря запущен на порту %s", port)
-	log.Printf("Health check: http://localhost:%s/health", port)
+	runErr := srv.Run(ctx)
+
+	// Отправляем накопленные спаны до завершения процесса
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(flushCtx); err != nil {
+		slog.Error("Ошибка остановки трассировки", "error", err)
+	}
+
+	if runErr != nil {
+		fatal("Ошибка работы сервера", "error", runErr)
+	}
 
-	if err := srv.Start(); err != nil {
-		log.Fatalf("Ошибка запуска сервера: %v", err)
+	slog.Info("сервер календаря остановлен")
+}
+
+// fatal записывает ошибку в журнал и завершает процесс
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// durationFromEnv читает положительную длительность из переменной окружения; 0, если переменная не задана
+func durationFromEnv(name string) time.Duration {
+	return envDuration(name, false)
+}
+
+// delayFromEnv читает паузу из переменной окружения; в отличие от таймаутов допускается 0
+func delayFromEnv(name string) time.Duration {
+	return envDuration(name, true)
+}
+
+// envDuration читает длительность из переменной окружения и завершает процесс, если значение некорректно
+func envDuration(name string, allowZero bool) time.Duration {
+	value := os.Getenv(name)
+	d, err := parseDuration(value, allowZero)
+	if err != nil {
+		fatal("Некорректное значение "+name, "value", value, "error", err)
+	}
+	return d
+}
+
+// parseDuration разбирает длительность; пустая строка означает 0.
+// Отрицательные значения не допускаются, нулевое - только при allowZero
+func parseDuration(value string, allowZero bool) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("длительность не может быть отрицательной: %s", value)
+	}
+	if d == 0 && !allowZero {
+		return 0, fmt.Errorf("длительность должна быть положительной: %s", value)
 	}
+	return d, nil
 }
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		allowZero   bool
+		expected    time.Duration
+		expectError bool
+	}{
+		{name: "Не задано", value: "", expected: 0},
+		{name: "Положительное значение", value: "15s", expected: 15 * time.Second},
+		{name: "Нулевой таймаут", value: "0s", expectError: true},
+		{name: "Нулевая пауза", value: "0s", allowZero: true, expected: 0},
+		{name: "Ноль без единиц", value: "0", allowZero: true, expected: 0},
+		{name: "Отрицательная пауза", value: "-5s", allowZero: true, expectError: true},
+		{name: "Некорректное значение", value: "пять секунд", allowZero: true, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := parseDuration(tt.value, tt.allowZero)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+}
+
+func TestDelayFromEnv_Zero(t *testing.T) {
+	// Значение по умолчанию из README, заданное явно, не останавливает запуск
+	t.Setenv("SHUTDOWN_DELAY", "0s")
+	assert.Equal(t, time.Duration(0), delayFromEnv("SHUTDOWN_DELAY"))
+}